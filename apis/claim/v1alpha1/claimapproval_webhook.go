@@ -0,0 +1,221 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	admissionV1 "k8s.io/api/admission/v1"
+	authenticationV1 "k8s.io/api/authentication/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var ClaimApprovalWebhookLogger = logf.Log.WithName("claimapproval-resource")
+
+// dry run은 생성하거나 변경할 resource를 확인하는 용도이므로 dryRun을 해제하기 전에는 승인할 수 없다.
+var errDryRunApproval = fmt.Errorf("claim in dry run cannot be approved. Set spec.dryRun to false before approval")
+
+const claimApprovalWebhookPath = "/validate-claim-tmax-io-v1alpha1-claim-approval"
+
+const (
+	// claim을 생성한 사용자. claim을 생성할 때 webhook이 기록한다.
+	AnnotationKeyClaimCreator = "creator"
+	// claim을 승인 또는 거절한 사용자. status.approver를 controller가 기록한다.
+	AnnotationKeyClaimApprover = "claim.tmax.io/approver"
+)
+
+var (
+	// ClaimApproverGroups는 claim을 승인 또는 거절할 수 있는 group 목록. 비어있으면 검사하지 않는다.
+	ClaimApproverGroups []string
+	// ForbidSelfApproval이 true이면 claim을 생성한 사용자는 자신의 claim을 승인 또는 거절할 수 없다.
+	ForbidSelfApproval bool
+	// ClaimControllerUsername은 operator의 service account 사용자 이름
+	// policy에 의한 자동 승인과 같이 operator가 변경하는 경우에는 검사하지 않는다. 비어있으면 operator로 간주하는 사용자가 없다.
+	ClaimControllerUsername string
+)
+
+// SetupClaimApprovalWebhookWithManager는 claim의 승인/거절 권한을 검사하는 webhook을 등록한다.
+// webhook.Validator로는 요청한 사용자 정보를 알 수 없으므로 admission handler를 직접 등록한다.
+func SetupClaimApprovalWebhookWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(claimApprovalWebhookPath, &webhook.Admission{Handler: &ClaimApprovalValidator{}})
+}
+
+// +kubebuilder:webhook:path=/validate-claim-tmax-io-v1alpha1-claim-approval,mutating=false,failurePolicy=fail,groups=claim.tmax.io,resources=clusterclaims/status;clusterupdateclaims/status,verbs=update,versions=v1alpha1,name=validation.webhook.claimapproval,admissionReviewVersions=v1beta1;v1,sideEffects=NoneOnDryRun
+
+// ClaimApprovalValidator는 cluster claim과 cluster update claim의 phase를 approved 또는 rejected로
+// 변경하는 사용자가 관리자 group에 속하는지, claim을 생성한 사용자가 아닌지 검사한다.
+// +kubebuilder:object:generate=false
+type ClaimApprovalValidator struct {
+	decoder *admission.Decoder
+}
+
+var _ admission.Handler = &ClaimApprovalValidator{}
+var _ admission.DecoderInjector = &ClaimApprovalValidator{}
+
+func (v *ClaimApprovalValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
+
+func (v *ClaimApprovalValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionV1.Update {
+		return admission.Allowed("")
+	}
+
+	var err error
+	switch req.Kind.Kind {
+	case "ClusterClaim":
+		cc, old := &ClusterClaim{}, &ClusterClaim{}
+		if err := v.decoder.Decode(req, cc); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if err := v.decoder.DecodeRaw(req.OldObject, old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		err = validateClusterClaimApproval(cc, old, req.UserInfo)
+	case "ClusterUpdateClaim":
+		cuc, old := &ClusterUpdateClaim{}, &ClusterUpdateClaim{}
+		if err := v.decoder.Decode(req, cuc); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if err := v.decoder.DecodeRaw(req.OldObject, old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if cuc.Spec.DryRun && old.Status.Phase != cuc.Status.Phase && cuc.IsPhaseApproved() {
+			err = errDryRunApproval
+		} else if old.Status.Phase != cuc.Status.Phase && (cuc.IsPhaseApproved() || cuc.IsPhaseRejected()) {
+			err = validateApprover(req.UserInfo, cuc.Annotations[AnnotationKeyClaimCreator])
+		}
+	}
+
+	if err != nil {
+		ClaimApprovalWebhookLogger.Info("deny approval", "kind", req.Kind.Kind, "name", req.Name,
+			"namespace", req.Namespace, "user", req.UserInfo.Username, "reason", err.Error())
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+// validateClusterClaimApproval은 승인 stage가 있으면 stage별 group으로, 없으면 관리자 group으로 검사한다.
+// webhook이 승인된 stage를 기록한 이후에 검사하므로, 마지막이 아닌 stage의 승인은 phase가 바뀌지 않고 stage의 approver만 바뀐다.
+func validateClusterClaimApproval(cc, old *ClusterClaim, user authenticationV1.UserInfo) error {
+	// policy와 승인 stage를 적용하기 전에 승인하지 못하도록 처음 phase는 operator만 변경할 수 있다.
+	if old.Status.Phase == "" && cc.Status.Phase != "" && !isClaimControllerUser(user) {
+		return fmt.Errorf("claim is not awaiting approval yet")
+	}
+
+	if cc.Spec.DryRun {
+		approved := old.Status.Phase != cc.Status.Phase && cc.Status.Phase == ClusterClaimPhaseApproved
+		for i := range cc.Status.ApprovalStages {
+			if i < len(old.Status.ApprovalStages) && old.Status.ApprovalStages[i].Approver == "" &&
+				cc.Status.ApprovalStages[i].Approver != "" {
+				approved = true
+			}
+		}
+		if approved {
+			return errDryRunApproval
+		}
+	}
+
+	for i := range cc.Status.ApprovalStages {
+		if i < len(old.Status.ApprovalStages) && old.Status.ApprovalStages[i].Approver == "" &&
+			cc.Status.ApprovalStages[i].Approver != "" {
+			return validateStageApprover(user, cc, &old.Status.ApprovalStages[i])
+		}
+	}
+
+	if old.Status.Phase == cc.Status.Phase ||
+		(cc.Status.Phase != ClusterClaimPhaseApproved && cc.Status.Phase != ClusterClaimPhaseRejected) {
+		return nil
+	}
+	// 거절은 승인을 기다리는 stage의 group이 할 수 있다.
+	if stage := old.GetCurrentApprovalStage(); stage != nil {
+		return validateStageApprover(user, cc, stage)
+	}
+	return validateApprover(user, cc.Annotations[AnnotationKeyClaimCreator])
+}
+
+// validateStageApprover는 사용자가 stage의 group에 속하는지, 다른 stage를 이미 승인하지 않았는지 검사한다.
+func validateStageApprover(user authenticationV1.UserInfo, cc *ClusterClaim, stage *ClaimApprovalStage) error {
+	if isClaimControllerUser(user) {
+		return nil
+	}
+
+	if !containsAnyGroup(stage.Groups, user.Groups) {
+		return fmt.Errorf("user [%s] is not allowed to approve or reject stage [%s]. Allowed groups: %s",
+			user.Username, stage.Name, strings.Join(stage.Groups, ", "))
+	}
+
+	// 여러 stage를 한 사용자가 승인하면 여러 단계의 승인을 받는 의미가 없다.
+	for _, s := range cc.Status.ApprovalStages {
+		if s.Name != stage.Name && s.Approver == user.Username {
+			return fmt.Errorf("user [%s] already approved stage [%s]", user.Username, s.Name)
+		}
+	}
+
+	return validateSelfApproval(user, cc.Annotations[AnnotationKeyClaimCreator])
+}
+
+// isClaimControllerUser는 operator의 요청인지 확인한다.
+// operator의 사용자 이름을 알 수 없는 경우에는 어떤 사용자도 operator로 간주하지 않는다.
+func isClaimControllerUser(user authenticationV1.UserInfo) bool {
+	return ClaimControllerUsername != "" && user.Username == ClaimControllerUsername
+}
+
+// validateApprover는 사용자가 claim을 승인 또는 거절할 수 있는지 검사한다.
+func validateApprover(user authenticationV1.UserInfo, creator string) error {
+	if isClaimControllerUser(user) {
+		return nil
+	}
+
+	if len(ClaimApproverGroups) > 0 && !containsAnyGroup(ClaimApproverGroups, user.Groups) {
+		return fmt.Errorf("user [%s] is not allowed to approve or reject claims. Allowed groups: %s",
+			user.Username, strings.Join(ClaimApproverGroups, ", "))
+	}
+
+	return validateSelfApproval(user, creator)
+}
+
+// validateSelfApproval은 webhook이 기록한 claim 생성자가 자신의 claim을 승인 또는 거절하지 못하도록 한다.
+// 생성자가 기록되지 않은 claim은 생성자를 확인할 수 없으므로 거부한다.
+func validateSelfApproval(user authenticationV1.UserInfo, creator string) error {
+	if !ForbidSelfApproval {
+		return nil
+	}
+	if creator == "" {
+		return fmt.Errorf("creator of the claim is unknown. Claims without annotation [%s] cannot be approved or rejected", AnnotationKeyClaimCreator)
+	}
+	if creator == user.Username {
+		return fmt.Errorf("user [%s] cannot approve or reject own claim", user.Username)
+	}
+	return nil
+}
+
+func containsAnyGroup(allowed, groups []string) bool {
+	for _, a := range allowed {
+		for _, g := range groups {
+			if a == g {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,353 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	admissionV1 "k8s.io/api/admission/v1"
+	authenticationV1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const testOperatorUsername = "system:serviceaccount:hypercloud5-system:hypercloud-multi-operator"
+
+var (
+	testOperator = authenticationV1.UserInfo{Username: testOperatorUsername}
+	testAdmin    = authenticationV1.UserInfo{Username: "admin@tmax.co.kr", Groups: []string{"hypercloud5"}}
+	testLead     = authenticationV1.UserInfo{Username: "lead@tmax.co.kr", Groups: []string{"leads"}}
+	testOps      = authenticationV1.UserInfo{Username: "ops@tmax.co.kr", Groups: []string{"ops"}}
+	testCreator  = authenticationV1.UserInfo{Username: "user@tmax.co.kr", Groups: []string{"hypercloud5", "leads"}}
+)
+
+// setClaimApprovalConfig는 test 동안 승인 설정을 변경하고, 원래대로 되돌리는 함수를 반환한다.
+func setClaimApprovalConfig() func() {
+	username, groups, forbid := ClaimControllerUsername, ClaimApproverGroups, ForbidSelfApproval
+	ClaimControllerUsername = testOperatorUsername
+	ClaimApproverGroups = []string{"hypercloud5"}
+	ForbidSelfApproval = true
+	return func() {
+		ClaimControllerUsername, ClaimApproverGroups, ForbidSelfApproval = username, groups, forbid
+	}
+}
+
+func newTestApprovalValidator(t *testing.T) *ClaimApprovalValidator {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	decoder, err := admission.NewDecoder(scheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := &ClaimApprovalValidator{}
+	if err := v.InjectDecoder(decoder); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func newStatusUpdateRequest(t *testing.T, kind string, obj, old runtime.Object, user authenticationV1.UserInfo) admission.Request {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldRaw, err := json.Marshal(old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return admission.Request{AdmissionRequest: admissionV1.AdmissionRequest{
+		Operation:   admissionV1.Update,
+		Kind:        metav1.GroupVersionKind{Group: GroupVersion.Group, Version: GroupVersion.Version, Kind: kind},
+		SubResource: "status",
+		Object:      runtime.RawExtension{Raw: raw},
+		OldObject:   runtime.RawExtension{Raw: oldRaw},
+		UserInfo:    user,
+	}}
+}
+
+func newTestApprovalClaim(phase ClusterClaimPhase, stages ...ClaimApprovalStage) *ClusterClaim {
+	return &ClusterClaim{
+		TypeMeta: metav1.TypeMeta{APIVersion: GroupVersion.String(), Kind: "ClusterClaim"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "claim",
+			Namespace:   "default",
+			Annotations: map[string]string{AnnotationKeyClaimCreator: testCreator.Username},
+		},
+		Spec:   ClusterClaimSpec{ClusterName: "cluster", Provider: "AWS", MasterNum: 1, WorkerNum: 1},
+		Status: ClusterClaimStatus{Phase: phase, ApprovalStages: stages},
+	}
+}
+
+func TestClaimApprovalValidatorClusterClaim(t *testing.T) {
+	defer setClaimApprovalConfig()()
+	v := newTestApprovalValidator(t)
+
+	stages := func(approvers ...string) []ClaimApprovalStage {
+		s := []ClaimApprovalStage{
+			{Name: "team-lead", Groups: []string{"leads"}},
+			{Name: "ops", Groups: []string{"ops"}},
+		}
+		for i, approver := range approvers {
+			s[i].Approver = approver
+		}
+		return s
+	}
+
+	tests := []struct {
+		name    string
+		old     *ClusterClaim
+		new     *ClusterClaim
+		user    authenticationV1.UserInfo
+		allowed bool
+	}{
+		{
+			name:    "operator sets initial phase",
+			old:     newTestApprovalClaim(""),
+			new:     newTestApprovalClaim(ClusterClaimPhaseAwaiting),
+			user:    testOperator,
+			allowed: true,
+		},
+		{
+			name:    "admin cannot set initial phase",
+			old:     newTestApprovalClaim(""),
+			new:     newTestApprovalClaim(ClusterClaimPhaseApproved),
+			user:    testAdmin,
+			allowed: false,
+		},
+		{
+			name:    "admin approves",
+			old:     newTestApprovalClaim(ClusterClaimPhaseAwaiting),
+			new:     newTestApprovalClaim(ClusterClaimPhaseApproved),
+			user:    testAdmin,
+			allowed: true,
+		},
+		{
+			name:    "admin rejects",
+			old:     newTestApprovalClaim(ClusterClaimPhaseAwaiting),
+			new:     newTestApprovalClaim(ClusterClaimPhaseRejected),
+			user:    testAdmin,
+			allowed: true,
+		},
+		{
+			name:    "user not in approver groups",
+			old:     newTestApprovalClaim(ClusterClaimPhaseAwaiting),
+			new:     newTestApprovalClaim(ClusterClaimPhaseApproved),
+			user:    testOps,
+			allowed: false,
+		},
+		{
+			name:    "creator cannot approve own claim",
+			old:     newTestApprovalClaim(ClusterClaimPhaseAwaiting),
+			new:     newTestApprovalClaim(ClusterClaimPhaseApproved),
+			user:    testCreator,
+			allowed: false,
+		},
+		{
+			name: "claim without creator cannot be approved",
+			old: func() *ClusterClaim {
+				cc := newTestApprovalClaim(ClusterClaimPhaseAwaiting)
+				cc.Annotations = nil
+				return cc
+			}(),
+			new: func() *ClusterClaim {
+				cc := newTestApprovalClaim(ClusterClaimPhaseApproved)
+				cc.Annotations = nil
+				return cc
+			}(),
+			user:    testAdmin,
+			allowed: false,
+		},
+		{
+			name:    "operator approves by policy",
+			old:     newTestApprovalClaim(ClusterClaimPhaseAwaiting),
+			new:     newTestApprovalClaim(ClusterClaimPhaseApproved),
+			user:    testOperator,
+			allowed: true,
+		},
+		{
+			name: "dry run claim cannot be approved",
+			old: func() *ClusterClaim {
+				cc := newTestApprovalClaim(ClusterClaimPhaseAwaiting)
+				cc.Spec.DryRun = true
+				return cc
+			}(),
+			new: func() *ClusterClaim {
+				cc := newTestApprovalClaim(ClusterClaimPhaseApproved)
+				cc.Spec.DryRun = true
+				return cc
+			}(),
+			user:    testAdmin,
+			allowed: false,
+		},
+		{
+			name: "dry run claim cannot be approved by stage",
+			old: func() *ClusterClaim {
+				cc := newTestApprovalClaim(ClusterClaimPhaseAwaiting, stages()...)
+				cc.Spec.DryRun = true
+				return cc
+			}(),
+			new: func() *ClusterClaim {
+				cc := newTestApprovalClaim(ClusterClaimPhaseAwaiting, stages(testLead.Username)...)
+				cc.Spec.DryRun = true
+				return cc
+			}(),
+			user:    testLead,
+			allowed: false,
+		},
+		{
+			name:    "stage approver approves first stage",
+			old:     newTestApprovalClaim(ClusterClaimPhaseAwaiting, stages()...),
+			new:     newTestApprovalClaim(ClusterClaimPhaseAwaiting, stages(testLead.Username)...),
+			user:    testLead,
+			allowed: true,
+		},
+		{
+			name:    "user not in stage groups",
+			old:     newTestApprovalClaim(ClusterClaimPhaseAwaiting, stages()...),
+			new:     newTestApprovalClaim(ClusterClaimPhaseAwaiting, stages(testOps.Username)...),
+			user:    testOps,
+			allowed: false,
+		},
+		{
+			name:    "admin not in stage groups",
+			old:     newTestApprovalClaim(ClusterClaimPhaseAwaiting, stages()...),
+			new:     newTestApprovalClaim(ClusterClaimPhaseAwaiting, stages(testAdmin.Username)...),
+			user:    testAdmin,
+			allowed: false,
+		},
+		{
+			name:    "creator cannot approve own claim in stage",
+			old:     newTestApprovalClaim(ClusterClaimPhaseAwaiting, stages()...),
+			new:     newTestApprovalClaim(ClusterClaimPhaseAwaiting, stages(testCreator.Username)...),
+			user:    testCreator,
+			allowed: false,
+		},
+		{
+			name:    "stage approver approves last stage",
+			old:     newTestApprovalClaim(ClusterClaimPhaseAwaiting, stages(testLead.Username)...),
+			new:     newTestApprovalClaim(ClusterClaimPhaseApproved, stages(testLead.Username, testOps.Username)...),
+			user:    testOps,
+			allowed: true,
+		},
+		{
+			name: "same user cannot approve two stages",
+			old:  newTestApprovalClaim(ClusterClaimPhaseAwaiting, stages(testLead.Username)...),
+			new: func() *ClusterClaim {
+				cc := newTestApprovalClaim(ClusterClaimPhaseApproved, stages(testLead.Username, testLead.Username)...)
+				cc.Status.ApprovalStages[1].Groups = []string{"ops", "leads"}
+				return cc
+			}(),
+			user:    testLead,
+			allowed: false,
+		},
+		{
+			name:    "stage approver rejects current stage",
+			old:     newTestApprovalClaim(ClusterClaimPhaseAwaiting, stages(testLead.Username)...),
+			new:     newTestApprovalClaim(ClusterClaimPhaseRejected, stages(testLead.Username)...),
+			user:    testOps,
+			allowed: true,
+		},
+		{
+			name:    "approver of other stage cannot reject current stage",
+			old:     newTestApprovalClaim(ClusterClaimPhaseAwaiting, stages(testLead.Username)...),
+			new:     newTestApprovalClaim(ClusterClaimPhaseRejected, stages(testLead.Username)...),
+			user:    testLead,
+			allowed: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := v.Handle(context.Background(), newStatusUpdateRequest(t, "ClusterClaim", tt.new, tt.old, tt.user))
+			if resp.Allowed != tt.allowed {
+				t.Errorf("Handle() allowed = %v, want %v: %v", resp.Allowed, tt.allowed, resp.Result)
+			}
+		})
+	}
+}
+
+func TestClaimApprovalValidatorClusterUpdateClaim(t *testing.T) {
+	defer setClaimApprovalConfig()()
+	v := newTestApprovalValidator(t)
+
+	newClaim := func(phase ClusterUpdateClaimPhase, dryRun bool) *ClusterUpdateClaim {
+		return &ClusterUpdateClaim{
+			TypeMeta: metav1.TypeMeta{APIVersion: GroupVersion.String(), Kind: "ClusterUpdateClaim"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "update",
+				Namespace:   "default",
+				Annotations: map[string]string{AnnotationKeyClaimCreator: testCreator.Username},
+			},
+			Spec:   ClusterUpdateClaimSpec{ClusterName: "cluster", UpdatedWorkerNum: 3, DryRun: dryRun},
+			Status: ClusterUpdateClaimStatus{Phase: phase},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		old     *ClusterUpdateClaim
+		new     *ClusterUpdateClaim
+		user    authenticationV1.UserInfo
+		allowed bool
+	}{
+		{
+			name:    "admin approves",
+			old:     newClaim(ClusterUpdateClaimPhaseAwaiting, false),
+			new:     newClaim(ClusterUpdateClaimPhaseApproved, false),
+			user:    testAdmin,
+			allowed: true,
+		},
+		{
+			name:    "user not in approver groups",
+			old:     newClaim(ClusterUpdateClaimPhaseAwaiting, false),
+			new:     newClaim(ClusterUpdateClaimPhaseApproved, false),
+			user:    testOps,
+			allowed: false,
+		},
+		{
+			name:    "creator cannot reject own claim",
+			old:     newClaim(ClusterUpdateClaimPhaseAwaiting, false),
+			new:     newClaim(ClusterUpdateClaimPhaseRejected, false),
+			user:    testCreator,
+			allowed: false,
+		},
+		{
+			name:    "dry run claim cannot be approved",
+			old:     newClaim(ClusterUpdateClaimPhaseAwaiting, true),
+			new:     newClaim(ClusterUpdateClaimPhaseApproved, true),
+			user:    testAdmin,
+			allowed: false,
+		},
+		{
+			name:    "operator updates phase",
+			old:     newClaim(ClusterUpdateClaimPhaseApproved, false),
+			new:     newClaim(ClusterUpdateClaimPhaseError, false),
+			user:    testOperator,
+			allowed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := v.Handle(context.Background(), newStatusUpdateRequest(t, "ClusterUpdateClaim", tt.new, tt.old, tt.user))
+			if resp.Allowed != tt.allowed {
+				t.Errorf("Handle() allowed = %v, want %v: %v", resp.Allowed, tt.allowed, resp.Result)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	admissionV1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// claim을 생성한 사용자가 속한 group 목록, ','로 구분한다. claim을 생성할 때 webhook이 기록한다.
+const AnnotationKeyClaimCreatorGroups = "creatorGroups"
+
+const claimCreatorWebhookPath = "/mutate-claim-tmax-io-v1alpha1-claim-creator"
+
+// SetupClaimCreatorWebhookWithManager는 claim을 생성한 사용자를 annotation에 기록하는 webhook을 등록한다.
+// webhook.Defaulter로는 요청한 사용자 정보를 알 수 없으므로 admission handler를 직접 등록한다.
+func SetupClaimCreatorWebhookWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(claimCreatorWebhookPath, &webhook.Admission{Handler: &ClaimCreatorRecorder{}})
+}
+
+// +kubebuilder:webhook:path=/mutate-claim-tmax-io-v1alpha1-claim-creator,mutating=true,failurePolicy=fail,groups=claim.tmax.io,resources=clusterclaims;clusterupdateclaims,verbs=create,versions=v1alpha1,name=mutation.webhook.claimcreator,admissionReviewVersions=v1beta1;v1,sideEffects=NoneOnDryRun
+
+// ClaimCreatorRecorder는 claim을 생성한 사용자와 group을 요청의 사용자 정보로 기록한다.
+// policy에 의한 자동 승인, group별 quota, 자기 승인 금지는 이 값으로 판단하므로
+// 사용자가 입력한 값은 무시하고 덮어쓴다.
+// +kubebuilder:object:generate=false
+type ClaimCreatorRecorder struct {
+	decoder *admission.Decoder
+}
+
+var _ admission.Handler = &ClaimCreatorRecorder{}
+var _ admission.DecoderInjector = &ClaimCreatorRecorder{}
+
+func (h *ClaimCreatorRecorder) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	return nil
+}
+
+func (h *ClaimCreatorRecorder) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionV1.Create {
+		return admission.Allowed("")
+	}
+
+	var obj interface {
+		runtime.Object
+		metav1.Object
+	}
+	switch req.Kind.Kind {
+	case "ClusterClaim":
+		obj = &ClusterClaim{}
+	case "ClusterUpdateClaim":
+		obj = &ClusterUpdateClaim{}
+	default:
+		return admission.Allowed("")
+	}
+	if err := h.decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[AnnotationKeyClaimCreator] = req.UserInfo.Username
+	annotations[AnnotationKeyClaimCreatorGroups] = strings.Join(req.UserInfo.Groups, ",")
+	obj.SetAnnotations(annotations)
+	return patchResponse(req, obj)
+}
+
+// GetClaimCreatorGroups는 claim을 생성한 사용자가 속한 group 목록을 반환한다.
+func GetClaimCreatorGroups(obj metav1.Object) []string {
+	groups := []string{}
+	for _, group := range strings.Split(obj.GetAnnotations()[AnnotationKeyClaimCreatorGroups], ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// validateClaimCreatorUnchanged는 webhook이 기록한 claim 생성자를 변경하지 못하도록 한다.
+func validateClaimCreatorUnchanged(obj, old metav1.Object) error {
+	for _, key := range []string{AnnotationKeyClaimCreator, AnnotationKeyClaimCreatorGroups} {
+		if obj.GetAnnotations()[key] != old.GetAnnotations()[key] {
+			return fmt.Errorf("annotation [%s] cannot be modified", key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClaimHistoryRecord is a record of the phase change of the claim.
+// Records are appended by the admission webhook and cannot be modified or removed.
+type ClaimHistoryRecord struct {
+	// The time when the phase was changed.
+	Time metav1.Time `json:"time"`
+	// The name of the user who changed the phase.
+	User string `json:"user,omitempty"`
+	// The groups of the user who changed the phase.
+	Groups []string `json:"groups,omitempty"`
+	// The phase before the change.
+	PreviousPhase string `json:"previousPhase,omitempty"`
+	// The phase after the change.
+	Phase string `json:"phase"`
+	// The reason of the phase. Contains the rejection reason for Rejected phase.
+	Reason string `json:"reason,omitempty"`
+	// The message of the change. For ClusterUpdateClaim, the requested change of the cluster.
+	Message string `json:"message,omitempty"`
+	// The approval stage approved by the user.
+	Stage string `json:"stage,omitempty"`
+}
+
+// GetChangeSummary는 cluster update claim이 요청한 변경 내용을 "worker 3 -> 7"과 같은 형식으로 반환한다.
+// 현재 값은 claim이 awaiting이 될 때 status에 기록된 값을 사용한다.
+func (c *ClusterUpdateClaim) GetChangeSummary() string {
+	changes := []string{}
+	switch c.GetUpdateType() {
+	case ClusterUpdateTypeNodeScale:
+		if c.Spec.UpdatedMasterNum != 0 {
+			changes = append(changes, fmt.Sprintf("master %d -> %d", c.Status.CurrentMasterNum, c.Spec.UpdatedMasterNum))
+		}
+		if c.Spec.UpdatedWorkerNum != 0 {
+			changes = append(changes, fmt.Sprintf("worker %d -> %d", c.Status.CurrentWorkerNum, c.Spec.UpdatedWorkerNum))
+		}
+		pools := []string{}
+		for _, pool := range c.Spec.UpdatedWorkerPools {
+			pools = append(pools, fmt.Sprintf("pool %s %d -> %d", pool.Name, c.Status.CurrentWorkerPools[pool.Name], pool.Replicas))
+		}
+		sort.Strings(pools)
+		changes = append(changes, pools...)
+	case ClusterUpdateTypeExtension:
+		if c.Spec.ExtendTTL != nil {
+			current := "none"
+			if c.Status.CurrentExpiresAt != nil {
+				current = c.Status.CurrentExpiresAt.UTC().Format("2006-01-02T15:04:05Z")
+			}
+			changes = append(changes, fmt.Sprintf("expiresAt %s +%s", current, c.Spec.ExtendTTL.Duration))
+		}
+	case ClusterUpdateTypeUpgrade:
+		changes = append(changes, fmt.Sprintf("version %s -> %s", c.Status.CurrentVersion, c.Spec.UpdatedVersion))
+	case ClusterUpdateTypeDeletionProtection:
+		if c.Spec.UpdatedDeletionProtection != nil {
+			changes = append(changes, fmt.Sprintf("deletionProtection %t -> %t",
+				c.Status.CurrentDeletionProtection, *c.Spec.UpdatedDeletionProtection))
+		}
+	}
+	return strings.Join(changes, ", ")
+}
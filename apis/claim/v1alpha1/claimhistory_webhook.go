@@ -0,0 +1,175 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	admissionV1 "k8s.io/api/admission/v1"
+	authenticationV1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var ClaimHistoryWebhookLogger = logf.Log.WithName("claimhistory-resource")
+
+const claimHistoryWebhookPath = "/mutate-claim-tmax-io-v1alpha1-claim-status"
+
+// SetupClaimHistoryWebhookWithManager는 claim status의 phase 변경을 history에 기록하는 webhook을 등록한다.
+// webhook.Defaulter로는 요청한 사용자 정보를 알 수 없으므로 admission handler를 직접 등록한다.
+func SetupClaimHistoryWebhookWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(claimHistoryWebhookPath, &webhook.Admission{Handler: &ClaimHistoryRecorder{}})
+}
+
+// +kubebuilder:webhook:path=/mutate-claim-tmax-io-v1alpha1-claim-status,mutating=true,failurePolicy=fail,groups=claim.tmax.io,resources=clusterclaims/status;clusterupdateclaims/status,verbs=update,versions=v1alpha1,name=mutation.webhook.claimhistory,admissionReviewVersions=v1beta1;v1,sideEffects=NoneOnDryRun
+
+// ClaimHistoryRecorder는 cluster claim과 cluster update claim의 phase가 바뀔 때
+// 요청한 사용자와 시간, 이전/이후 phase를 status.history에 추가한다.
+// approved 또는 rejected로 바뀐 경우에는 요청한 사용자를 status.approver에 기록한다.
+// +kubebuilder:object:generate=false
+type ClaimHistoryRecorder struct {
+	decoder *admission.Decoder
+}
+
+var _ admission.Handler = &ClaimHistoryRecorder{}
+var _ admission.DecoderInjector = &ClaimHistoryRecorder{}
+
+func (h *ClaimHistoryRecorder) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	return nil
+}
+
+func (h *ClaimHistoryRecorder) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionV1.Update {
+		return admission.Allowed("")
+	}
+
+	switch req.Kind.Kind {
+	case "ClusterClaim":
+		cc, old := &ClusterClaim{}, &ClusterClaim{}
+		if err := h.decode(req, cc, old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		mutateClusterClaimStatus(cc, old, req.UserInfo)
+		return patchResponse(req, cc)
+	case "ClusterUpdateClaim":
+		cuc, old := &ClusterUpdateClaim{}, &ClusterUpdateClaim{}
+		if err := h.decode(req, cuc, old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		cuc.Status.History = appendClaimHistory(old.Status.History, req.UserInfo,
+			string(old.Status.Phase), string(cuc.Status.Phase), string(cuc.Status.Reason), cuc.GetChangeSummary())
+		cuc.Status.Approver, cuc.Status.ApprovalTime = old.Status.Approver, old.Status.ApprovalTime
+		if !isClaimControllerUser(req.UserInfo) {
+			cuc.Status.Plan = old.Status.Plan
+		}
+		if old.Status.Phase != cuc.Status.Phase && (cuc.IsPhaseApproved() || cuc.IsPhaseRejected()) {
+			cuc.Status.Approver, cuc.Status.ApprovalTime = req.UserInfo.Username, nowPtr()
+		}
+		return patchResponse(req, cuc)
+	}
+	return admission.Allowed("")
+}
+
+// mutateClusterClaimStatus는 승인된 stage와 승인/거절한 사용자, history를 기록한다.
+// 마지막 stage가 아니면 승인 요청을 받아도 phase를 유지하고 다음 stage의 승인을 기다린다.
+func mutateClusterClaimStatus(cc, old *ClusterClaim, user authenticationV1.UserInfo) {
+	// 승인 stage는 operator가 claim의 처음 phase를 변경할 때만 기록할 수 있다.
+	if old.Status.Phase != "" || len(old.Status.ApprovalStages) > 0 || !isClaimControllerUser(user) {
+		cc.Status.ApprovalPolicy = old.Status.ApprovalPolicy
+		cc.Status.ApprovalStages = append([]ClaimApprovalStage(nil), old.Status.ApprovalStages...)
+	}
+	// dry run의 plan과 estimate는 operator만 기록할 수 있다.
+	if !isClaimControllerUser(user) {
+		cc.Status.Plan = old.Status.Plan
+		cc.Status.Estimate = old.Status.Estimate
+	}
+
+	stage := ""
+	if old.Status.Phase != cc.Status.Phase && cc.Status.Phase == ClusterClaimPhaseApproved {
+		if current := cc.GetCurrentApprovalStage(); current != nil {
+			current.Approver, current.ApprovalTime = user.Username, nowPtr()
+			stage = current.Name
+			if next := cc.GetCurrentApprovalStage(); next != nil {
+				cc.Status.Phase = old.Status.Phase
+				cc.Status.Reason = fmt.Sprintf("Stage [%s] is approved by [%s]. Waiting for approval of stage [%s]",
+					current.Name, user.Username, next.Name)
+			}
+		}
+	}
+
+	cc.Status.History = old.Status.History
+	if old.Status.Phase != cc.Status.Phase || stage != "" {
+		record := newClaimHistoryRecord(user, string(old.Status.Phase), string(cc.Status.Phase), cc.Status.Reason, cc.Status.Message)
+		record.Stage = stage
+		cc.Status.History = append(cc.Status.History, record)
+	}
+
+	cc.Status.Approver, cc.Status.ApprovalTime = old.Status.Approver, old.Status.ApprovalTime
+	if old.Status.Phase != cc.Status.Phase &&
+		(cc.Status.Phase == ClusterClaimPhaseApproved || cc.Status.Phase == ClusterClaimPhaseRejected) {
+		cc.Status.Approver, cc.Status.ApprovalTime = user.Username, nowPtr()
+	}
+}
+
+func (h *ClaimHistoryRecorder) decode(req admission.Request, obj, old runtime.Object) error {
+	if err := h.decoder.Decode(req, obj); err != nil {
+		return err
+	}
+	return h.decoder.DecodeRaw(req.OldObject, old)
+}
+
+// 요청한 object의 history는 무시하고 이전 object의 history에 기록을 추가하므로,
+// 사용자가 history를 수정하거나 삭제할 수 없고 오래된 object로 update하더라도 기록이 유실되지 않는다.
+func appendClaimHistory(history []ClaimHistoryRecord, user authenticationV1.UserInfo,
+	previousPhase, phase, reason, message string) []ClaimHistoryRecord {
+	if previousPhase == phase {
+		return history
+	}
+	return append(history, newClaimHistoryRecord(user, previousPhase, phase, reason, message))
+}
+
+func newClaimHistoryRecord(user authenticationV1.UserInfo, previousPhase, phase, reason, message string) ClaimHistoryRecord {
+	ClaimHistoryWebhookLogger.Info("record claim history", "user", user.Username, "previousPhase", previousPhase, "phase", phase)
+	return ClaimHistoryRecord{
+		Time:          metav1.Now(),
+		User:          user.Username,
+		Groups:        user.Groups,
+		PreviousPhase: previousPhase,
+		Phase:         phase,
+		Reason:        reason,
+		Message:       message,
+	}
+}
+
+func nowPtr() *metav1.Time {
+	now := metav1.Now()
+	return &now
+}
+
+func patchResponse(req admission.Request, obj runtime.Object) admission.Response {
+	marshaled, err := json.Marshal(obj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
@@ -0,0 +1,131 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	authenticationV1 "k8s.io/api/authentication/v1"
+)
+
+func newTestApprovalStages() []ClaimApprovalStage {
+	return []ClaimApprovalStage{
+		{Name: "team-lead", Groups: []string{"leads"}},
+		{Name: "ops", Groups: []string{"ops"}},
+	}
+}
+
+// 마지막 stage가 승인될 때까지 phase는 awaiting을 유지하고 stage별 승인자만 기록한다.
+func TestMutateClusterClaimStatusMultiStage(t *testing.T) {
+	defer setClaimApprovalConfig()()
+
+	steps := []struct {
+		name          string
+		user          authenticationV1.UserInfo
+		phase         ClusterClaimPhase
+		wantPhase     ClusterClaimPhase
+		wantApprovers []string
+		wantApprover  string
+		wantStage     string
+	}{
+		{
+			name:          "first stage is approved",
+			user:          testLead,
+			phase:         ClusterClaimPhaseApproved,
+			wantPhase:     ClusterClaimPhaseAwaiting,
+			wantApprovers: []string{testLead.Username, ""},
+			wantStage:     "team-lead",
+		},
+		{
+			name:          "last stage is approved",
+			user:          testOps,
+			phase:         ClusterClaimPhaseApproved,
+			wantPhase:     ClusterClaimPhaseApproved,
+			wantApprovers: []string{testLead.Username, testOps.Username},
+			wantApprover:  testOps.Username,
+			wantStage:     "ops",
+		},
+	}
+
+	old := newTestApprovalClaim(ClusterClaimPhaseAwaiting, newTestApprovalStages()...)
+	for _, step := range steps {
+		cc := old.DeepCopy()
+		cc.Status.Phase = step.phase
+		mutateClusterClaimStatus(cc, old, step.user)
+
+		if cc.Status.Phase != step.wantPhase {
+			t.Errorf("%s: phase = %s, want %s", step.name, cc.Status.Phase, step.wantPhase)
+		}
+		for i, stage := range cc.Status.ApprovalStages {
+			if stage.Approver != step.wantApprovers[i] {
+				t.Errorf("%s: approver of stage [%s] = %q, want %q", step.name, stage.Name, stage.Approver, step.wantApprovers[i])
+			}
+		}
+		if cc.Status.Approver != step.wantApprover {
+			t.Errorf("%s: status.approver = %q, want %q", step.name, cc.Status.Approver, step.wantApprover)
+		}
+		if len(cc.Status.History) != len(old.Status.History)+1 || cc.Status.History[len(cc.Status.History)-1].Stage != step.wantStage {
+			t.Errorf("%s: history = %v, want a record of stage [%s]", step.name, cc.Status.History, step.wantStage)
+		}
+		old = cc
+	}
+}
+
+// operator가 아닌 사용자는 승인 stage를 직접 수정할 수 없다.
+func TestMutateClusterClaimStatusKeepsStages(t *testing.T) {
+	defer setClaimApprovalConfig()()
+
+	old := newTestApprovalClaim(ClusterClaimPhaseAwaiting, newTestApprovalStages()...)
+	old.Status.ApprovalStages[0].Approver = testLead.Username
+
+	cc := old.DeepCopy()
+	cc.Status.ApprovalStages = nil
+	mutateClusterClaimStatus(cc, old, testAdmin)
+
+	if len(cc.Status.ApprovalStages) != 2 || cc.Status.ApprovalStages[0].Approver != testLead.Username {
+		t.Errorf("approval stages = %v, want stages of the old claim", cc.Status.ApprovalStages)
+	}
+}
+
+// 일부 stage가 승인된 claim의 spec은 변경할 수 없다.
+func TestClusterClaimValidateUpdateAfterPartialApproval(t *testing.T) {
+	defer setClaimApprovalConfig()()
+
+	pending := newTestApprovalClaim(ClusterClaimPhaseAwaiting, newTestApprovalStages()...)
+	partial := pending.DeepCopy()
+	partial.Status.Phase = ClusterClaimPhaseApproved
+	mutateClusterClaimStatus(partial, pending, testLead)
+	if partial.Status.Phase != ClusterClaimPhaseAwaiting {
+		t.Fatalf("phase after partial approval = %s, want %s", partial.Status.Phase, ClusterClaimPhaseAwaiting)
+	}
+
+	tests := []struct {
+		name    string
+		old     *ClusterClaim
+		wantErr bool
+	}{
+		{name: "no stage is approved", old: pending, wantErr: false},
+		{name: "first stage is approved", old: partial, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := tt.old.DeepCopy()
+			updated.Spec.WorkerNum = 3
+			if err := updated.ValidateUpdate(tt.old); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClaimPlan is the result of the dry run of the claim.
+// The rendered resources are written to the ConfigMap and nothing is created.
+type ClaimPlan struct {
+	// The name of the ConfigMap which contains the rendered resources.
+	ConfigMap string `json:"configMap,omitempty"`
+	// The generation of the claim from which the plan was rendered.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// The time when the plan was rendered.
+	Time metav1.Time `json:"time,omitempty"`
+	// The summary of the changes. Example: KubeadmControlPlane test-control-plane replicas 3 -> 5
+	Summary []string `json:"summary,omitempty"`
+	// The reason why the plan could not be rendered.
+	Message string `json:"message,omitempty"`
+}
@@ -15,6 +15,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	coreV1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -35,6 +36,8 @@ const (
 	ClusterClaimPhaseClusterDeleted = ClusterClaimPhase("Cluster Deleted")
 	// 클러스터 생성과정에서 에러가 발생한 상태
 	ClusterClaimPhaseError = ClusterClaimPhase("Error")
+	// 클러스터가 만료되어 삭제된 상태
+	ClusterClaimPhaseExpired = ClusterClaimPhase("Expired")
 )
 
 const (
@@ -50,22 +53,69 @@ type ClusterClaimSpec struct {
 	// +kubebuilder:validation:Pattern:=^v[0-9].[0-9]+.[0-9]+
 	// The version of kubernetes. Example: v1.19.6
 	Version string `json:"version"`
-	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum:=AWS;vSphere
-	// The type of provider.
-	Provider string `json:"provider"`
-	// +kubebuilder:validation:Required
+	// The reference to ClusterClaimTemplate. The values of the template are used for the fields not specified in the claim.
+	TemplateRef *ClusterClaimTemplateReference `json:"templateRef,omitempty"`
+	// +kubebuilder:validation:Enum:=AWS;vSphere;OpenStack;Docker
+	// The type of provider. Required if templateRef is not specified.
+	Provider string `json:"provider,omitempty"`
 	// +kubebuilder:validation:Minimum:=1
-	// The number of master node. Example: 3
-	MasterNum int `json:"masterNum"`
-	// +kubebuilder:validation:Required
+	// The number of master node. Required if templateRef is not specified. Example: 3
+	MasterNum int `json:"masterNum,omitempty"`
 	// +kubebuilder:validation:Minimum:=1
-	// The number of worker node. Example: 2
-	WorkerNum int `json:"workerNum"`
+	// The number of worker node. Required if templateRef is not specified. Example: 2
+	WorkerNum int `json:"workerNum,omitempty"`
+	// +listType=map
+	// +listMapKey=name
+	// The additional worker pools. The default pool is created with workerNum.
+	WorkerPools []WorkerPoolClaimSpec `json:"workerPools,omitempty"`
 	// Provider Aws Spec.
 	ProviderAwsSpec AwsClaimSpec `json:"providerAwsSpec,omitempty"`
 	// Provider vSphere Spec.
 	ProviderVsphereSpec VsphereClaimSpec `json:"providerVsphereSpec,omitempty"`
+	// Provider OpenStack Spec.
+	ProviderOpenstackSpec OpenstackClaimSpec `json:"providerOpenstackSpec,omitempty"`
+	// Provider Docker Spec.
+	ProviderDockerSpec DockerClaimSpec `json:"providerDockerSpec,omitempty"`
+	// The lifetime of cluster from approval. The cluster is deleted when it expires. Example: 72h
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+	// The time when the cluster expires and is deleted. Cannot be used with ttl.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+	// If true, the resources to create are rendered to status.plan and nothing is created.
+	// The claim cannot be approved until dryRun is disabled.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+type ClusterClaimTemplateReference struct {
+	// +kubebuilder:validation:Required
+	// The name of ClusterClaimTemplate.
+	Name string `json:"name"`
+	// The name of size in the template. Defaults to defaultSize of the template.
+	Size string `json:"size,omitempty"`
+}
+
+type WorkerPoolClaimSpec struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern:=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// The name of worker pool. Example: gpu
+	Name string `json:"name"`
+	// +kubebuilder:validation:Minimum:=0
+	// The number of worker node in the pool. Example: 2
+	Replicas int `json:"replicas"`
+	// The type of VM for aws. Defaults to workerType of providerAwsSpec.
+	InstanceType string `json:"instanceType,omitempty"`
+	// +kubebuilder:validation:Minimum:=8
+	// The disk size of VM, write as GB without unit. Defaults to the disk size of worker node.
+	DiskSize int `json:"diskSize,omitempty"`
+	// +kubebuilder:validation:Minimum:=2
+	// The number of cpus for vsphere vm. Defaults to vcenterCpuNum of providerVsphereSpec.
+	CpuNum int `json:"cpuNum,omitempty"`
+	// +kubebuilder:validation:Minimum:=2048
+	// The memory size for vsphere vm, write as MB without unit. Defaults to vcenterMemSize of providerVsphereSpec.
+	MemSize int `json:"memSize,omitempty"`
+	// The labels to be added to the nodes in the pool.
+	Labels map[string]string `json:"labels,omitempty"`
+	// The taints to be added to the nodes in the pool.
+	Taints []coreV1.Taint `json:"taints,omitempty"`
 }
 
 type AwsClaimSpec struct {
@@ -121,13 +171,101 @@ type VsphereClaimSpec struct {
 	VMPassword string `json:"vmPassword,omitempty"`
 }
 
+type OpenstackClaimSpec struct {
+	// The name of secret which has clouds.yaml for authentication. The secret must be in the same namespace.
+	CloudsSecretName string `json:"cloudsSecretName,omitempty"`
+	// The name of cloud in clouds.yaml. Defaults to openstack.
+	CloudName string `json:"cloudName,omitempty"`
+	// The flavor of VM for master node. Defaults to m1.medium.
+	MasterFlavor string `json:"masterFlavor,omitempty"`
+	// The flavor of VM for worker node. Defaults to m1.medium.
+	WorkerFlavor string `json:"workerFlavor,omitempty"`
+	// The name of image to create VM. Example: ubuntu-2004-kube-v1.19.6
+	Image string `json:"image,omitempty"`
+	// The name of existing network for nodes. If empty, a new network is created with nodeCidr.
+	Network string `json:"network,omitempty"`
+	// +kubebuilder:validation:Pattern:=^[0-9]+.[0-9]+.[0-9]+.[0-9]+\/[0-9]+
+	// The cidr block of the network created for nodes. Defaults to 10.6.0.0/24.
+	NodeCidr string `json:"nodeCidr,omitempty"`
+	// The id of external network to allocate floating IP for api server.
+	FloatingIpPool string `json:"floatingIpPool,omitempty"`
+	// The name of ssh key pair to access VM.
+	SshKey string `json:"sshKey,omitempty"`
+}
+
+type DockerClaimSpec struct {
+	// The repository of kind node image. The tag is kubernetes version. Defaults to kindest/node.
+	NodeImageRepository string `json:"nodeImageRepository,omitempty"`
+	// The host directories mounted to node containers.
+	ExtraMounts []DockerMountClaimSpec `json:"extraMounts,omitempty"`
+}
+
+type DockerMountClaimSpec struct {
+	// The path of host directory.
+	HostPath string `json:"hostPath"`
+	// The path in node container.
+	ContainerPath string `json:"containerPath"`
+	// Whether the mount is read-only.
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
+
 // ClusterClaimStatus defines the observed state of ClusterClaim
 type ClusterClaimStatus struct {
 	Message string `json:"message,omitempty" protobuf:"bytes,2,opt,name=message"`
 	Reason  string `json:"reason,omitempty" protobuf:"bytes,3,opt,name=reason"`
 
-	// +kubebuilder:validation:Enum=Awaiting;Admitted;Approved;Rejected;Error;ClusterDeleted;Cluster Deleted;
+	// +kubebuilder:validation:Enum=Awaiting;Admitted;Approved;Rejected;Error;ClusterDeleted;Cluster Deleted;Expired;
 	Phase ClusterClaimPhase `json:"phase,omitempty" protobuf:"bytes,4,opt,name=phase"`
+
+	// The user who approved or rejected the claim. Set by the webhook.
+	Approver string `json:"approver,omitempty"`
+	// The time when the claim was approved or rejected. Set by the webhook.
+	ApprovalTime *metav1.Time `json:"approvalTime,omitempty"`
+	// The ClusterClaimApprovalPolicy which requires the approval stages.
+	ApprovalPolicy string `json:"approvalPolicy,omitempty"`
+	// The stages to approve in order. Approver of the stage is set by the webhook when the stage is approved.
+	ApprovalStages []ClaimApprovalStage `json:"approvalStages,omitempty"`
+	// The estimated resources and cost of the cluster. Computed when the claim is created and recomputed when the spec is changed before approval.
+	Estimate *ClusterClaimEstimate `json:"estimate,omitempty"`
+	// The resources rendered by the dry run. Set when spec.dryRun is true.
+	Plan *ClaimPlan `json:"plan,omitempty"`
+
+	// The history of the phase changes. Appended by the webhook when the phase is changed.
+	History []ClaimHistoryRecord `json:"history,omitempty"`
+}
+
+// ClusterClaimEstimate is the estimated resources and cost of the cluster.
+type ClusterClaimEstimate struct {
+	// The generation of the claim from which the estimate was computed.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// The total number of nodes.
+	NodeNum int `json:"nodeNum"`
+	// The total number of vCPUs.
+	CpuNum int `json:"cpuNum,omitempty"`
+	// The total memory size, MB.
+	MemSize int `json:"memSize,omitempty"`
+	// The total disk size, GB.
+	DiskSize int `json:"diskSize,omitempty"`
+	// The currency of the cost. Example: USD
+	Currency string `json:"currency,omitempty"`
+	// The estimated cost per hour. Example: 1.25
+	HourlyCost string `json:"hourlyCost,omitempty"`
+	// The estimated cost per month of 730 hours. Example: 912.50
+	MonthlyCost string `json:"monthlyCost,omitempty"`
+	// The reason why the estimate is incomplete.
+	Message string `json:"message,omitempty"`
+}
+
+// ClaimApprovalStage is the approval state of the stage.
+type ClaimApprovalStage struct {
+	// The name of the stage.
+	Name string `json:"name"`
+	// The groups of users who can approve or reject the claim in this stage.
+	Groups []string `json:"groups"`
+	// The user who approved the stage. Empty if the stage is not approved yet.
+	Approver string `json:"approver,omitempty"`
+	// The time when the stage was approved.
+	ApprovalTime *metav1.Time `json:"approvalTime,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -135,6 +273,8 @@ type ClusterClaimStatus struct {
 // +kubebuilder:resource:path=clusterclaims,shortName=cc,scope=Namespaced
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.phase`
 // +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.reason`
+// +kubebuilder:printcolumn:name="Approver",type=string,JSONPath=`.status.approver`,priority=1
+// +kubebuilder:printcolumn:name="MonthlyCost",type=string,JSONPath=`.status.estimate.monthlyCost`,priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // ClusterClaim is the Schema for the clusterclaims API
 type ClusterClaim struct {
@@ -178,3 +318,29 @@ func (c *ClusterClaim) GetClusterManagerNamespacedName() types.NamespacedName {
 		Namespace: c.Namespace,
 	}
 }
+
+// GetCurrentApprovalStage는 승인을 기다리는 첫번째 stage를 반환한다.
+// 모든 stage가 승인되었거나 stage가 없으면 nil을 반환한다.
+func (c *ClusterClaim) GetCurrentApprovalStage() *ClaimApprovalStage {
+	for i := range c.Status.ApprovalStages {
+		if c.Status.ApprovalStages[i].Approver == "" {
+			return &c.Status.ApprovalStages[i]
+		}
+	}
+	return nil
+}
+
+// IsApprovalStarted는 승인된 stage가 하나라도 있는지 확인한다.
+func (c *ClusterClaim) IsApprovalStarted() bool {
+	for _, stage := range c.Status.ApprovalStages {
+		if stage.Approver != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// IsApprovalCompleted는 모든 승인 stage가 승인되었는지 확인한다.
+func (c *ClusterClaim) IsApprovalCompleted() bool {
+	return c.GetCurrentApprovalStage() == nil
+}
@@ -20,8 +20,11 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -67,7 +70,12 @@ var _ webhook.Validator = &ClusterClaim{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *ClusterClaim) ValidateCreate() error {
 	ClusterClaimWebhookLogger.Info("validate create", "name", r.Name)
+	return r.validateSpec()
+}
 
+// validateSpec은 cluster claim의 spec을 검증한다.
+// 승인 전에 spec을 변경하는 경우에도 quota 등을 우회하지 못하도록 생성할 때와 같이 검증한다.
+func (r *ClusterClaim) validateSpec() error {
 	// k8s 리소스들의 이름은 기본적으로 DNS-1123의 룰을 따라야 함
 	// 자세한 내용은 https://kubernetes.io/ko/docs/concepts/overview/working-with-objects/names/ 참조
 	// cluster manager 리소스는 cluster claim의 spec.clusterName을 metadata.name으로 가지게 되므로
@@ -113,13 +121,85 @@ func (r *ClusterClaim) ValidateCreate() error {
 		return k8sErrors.NewInvalid(r.GroupVersionKind().GroupKind(), "InvalidSpecClusterName", errList)
 	}
 
-	if r.Spec.MasterNum%2 == 0 {
+	// template을 참조하는 경우 provider, node 수는 template의 값을 사용할 수 있다.
+	if r.Spec.TemplateRef == nil {
+		errList := field.ErrorList{}
+		if r.Spec.Provider == "" {
+			errList = append(errList, field.Required(field.NewPath("spec", "provider"), "provider is required if templateRef is not specified"))
+		}
+		if r.Spec.MasterNum == 0 {
+			errList = append(errList, field.Required(field.NewPath("spec", "masterNum"), "masterNum is required if templateRef is not specified"))
+		}
+		if r.Spec.WorkerNum == 0 {
+			errList = append(errList, field.Required(field.NewPath("spec", "workerNum"), "workerNum is required if templateRef is not specified"))
+		}
+		if len(errList) > 0 {
+			return k8sErrors.NewInvalid(r.GroupVersionKind().GroupKind(), "InvalidSpec", errList)
+		}
+	}
+
+	if r.Spec.MasterNum != 0 && r.Spec.MasterNum%2 == 0 {
 		return errors.New("Cannot be an even number when using managed etcd")
 	}
 
+	if err := validateWorkerPoolNames(r.Spec.WorkerPools, field.NewPath("spec", "workerPools")); err != nil {
+		return k8sErrors.NewInvalid(r.GroupVersionKind().GroupKind(), "InvalidSpecWorkerPools", field.ErrorList{err})
+	}
+
+	if errList := validateExpiry(r.Spec.TTL, r.Spec.ExpiresAt); len(errList) > 0 {
+		return k8sErrors.NewInvalid(r.GroupVersionKind().GroupKind(), "InvalidSpecExpiry", errList)
+	}
+
+	// template을 참조하는 경우 template의 값과 합친 뒤 controller에서 검증한다.
+	if r.Spec.TemplateRef == nil && ProviderSpecValidator != nil {
+		if errList := ProviderSpecValidator(r); len(errList) > 0 {
+			return k8sErrors.NewInvalid(r.GroupVersionKind().GroupKind(), "InvalidSpecProvider", errList)
+		}
+	}
+
+	if ClusterClaimQuotaValidator != nil {
+		if errList := ClusterClaimQuotaValidator(r); len(errList) > 0 {
+			return k8sErrors.NewInvalid(r.GroupVersionKind().GroupKind(), "ExceededClusterQuota", errList)
+		}
+	}
+
+	return nil
+}
+
+// ProviderSpecValidator는 provider별 spec을 검증하는 함수
+// apis package에서 provider package를 import할 수 없으므로 manager를 시작할 때 설정한다.
+var ProviderSpecValidator func(cc *ClusterClaim) field.ErrorList
+
+// ClusterClaimQuotaValidator는 cluster claim이 ClusterQuota를 초과하는지 검증하는 함수
+// quota 사용량을 계산하려면 client가 필요하므로 manager를 시작할 때 설정한다.
+var ClusterClaimQuotaValidator func(cc *ClusterClaim) field.ErrorList
+
+func validateWorkerPoolNames(pools []WorkerPoolClaimSpec, path *field.Path) *field.Error {
+	for i, pool := range pools {
+		for _, reserved := range clusterV1alpha1.ReservedWorkerPoolNames {
+			if pool.Name == reserved {
+				return field.Invalid(path.Index(i).Child("name"), pool.Name, "worker pool name is reserved")
+			}
+		}
+	}
 	return nil
 }
 
+// ttl과 expiresAt은 함께 사용할 수 없고, 만료 시각은 현재 이후여야 한다.
+func validateExpiry(ttl *metav1.Duration, expiresAt *metav1.Time) field.ErrorList {
+	errList := field.ErrorList{}
+	if ttl != nil && expiresAt != nil {
+		errList = append(errList, field.Forbidden(field.NewPath("spec", "expiresAt"), "cannot be used with ttl"))
+	}
+	if ttl != nil && ttl.Duration <= 0 {
+		errList = append(errList, field.Invalid(field.NewPath("spec", "ttl"), ttl.Duration.String(), "must be greater than 0"))
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		errList = append(errList, field.Invalid(field.NewPath("spec", "expiresAt"), expiresAt.String(), "must be in the future"))
+	}
+	return errList
+}
+
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *ClusterClaim) ValidateUpdate(old runtime.Object) error {
 	ClusterClaimWebhookLogger.Info("validate update", "name", r.Name)
@@ -129,12 +209,21 @@ func (r *ClusterClaim) ValidateUpdate(old runtime.Object) error {
 		return nil
 	}
 
-	if oldClusterClaim.Status.Phase == ClusterClaimPhaseApproved || oldClusterClaim.Status.Phase == ClusterClaimPhaseClusterDeleted {
-		if !reflect.DeepEqual(oldClusterClaim.Spec, r.Spec) {
-			return errors.New("cannot modify clusterClaim after approval")
-		}
+	if err := validateClaimCreatorUnchanged(r, oldClusterClaim); err != nil {
+		return err
 	}
-	return nil
+
+	if reflect.DeepEqual(oldClusterClaim.Spec, r.Spec) {
+		return nil
+	}
+
+	// 승인된 stage가 있으면 승인한 spec이 변경되지 않도록 한다.
+	if oldClusterClaim.Status.Phase == ClusterClaimPhaseApproved || oldClusterClaim.Status.Phase == ClusterClaimPhaseClusterDeleted ||
+		oldClusterClaim.IsApprovalStarted() {
+		return errors.New("cannot modify clusterClaim after approval")
+	}
+	// dry run을 해제하면 quota 사용량에 포함되지 않던 claim이 포함되므로 다시 검증한다.
+	return r.validateSpec()
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
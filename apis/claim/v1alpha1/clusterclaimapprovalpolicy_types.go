@@ -0,0 +1,65 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterClaimApprovalPolicySpec defines the desired state of ClusterClaimApprovalPolicy
+// 비어있는 조건은 검사하지 않으며, 모든 조건을 만족하는 cluster claim은 stages의 순서대로 승인되어야 한다.
+type ClusterClaimApprovalPolicySpec struct {
+	// The namespaces of cluster claim. Empty means all namespaces.
+	// Labels of cluster claim are not used to select policies, because the claimant can change them.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// +kubebuilder:validation:MinItems:=1
+	// +listType=map
+	// +listMapKey=name
+	// The stages to approve in order. The cluster is created after all stages are approved.
+	Stages []ApprovalStageSpec `json:"stages"`
+}
+
+type ApprovalStageSpec struct {
+	// +kubebuilder:validation:Required
+	// The name of the stage. Example: team-lead
+	Name string `json:"name"`
+	// +kubebuilder:validation:MinItems:=1
+	// The groups of users who can approve or reject the claim in this stage.
+	Groups []string `json:"groups"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=clusterclaimapprovalpolicies,shortName=ccap,scope=Cluster
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// ClusterClaimApprovalPolicy is the Schema for the clusterclaimapprovalpolicies API
+// 조건을 만족하는 cluster claim은 여러 단계의 승인을 받아야 cluster가 생성된다.
+type ClusterClaimApprovalPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterClaimApprovalPolicySpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+// ClusterClaimApprovalPolicyList contains a list of ClusterClaimApprovalPolicy
+type ClusterClaimApprovalPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterClaimApprovalPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterClaimApprovalPolicy{}, &ClusterClaimApprovalPolicyList{})
+}
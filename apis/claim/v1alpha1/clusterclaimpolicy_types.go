@@ -0,0 +1,81 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterClaimPolicySpec defines the desired state of ClusterClaimPolicy
+// 비어있는 조건은 검사하지 않으며, 모든 조건을 만족하는 cluster claim은 자동으로 승인된다.
+type ClusterClaimPolicySpec struct {
+	// The namespaces of cluster claim. Empty means all namespaces.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// The users who create cluster claim. Matched with the creator annotation recorded by the webhook when cluster claim is created.
+	Users []string `json:"users,omitempty"`
+	// The groups of users who create cluster claim. Matched with the creatorGroups annotation recorded by the webhook when cluster claim is created.
+	// Cluster claim matches if the creator is in users or groups.
+	Groups []string `json:"groups,omitempty"`
+	// +kubebuilder:validation:items:Enum:=AWS;vSphere;OpenStack;Docker
+	// The allowed providers. Empty means all providers.
+	Providers []string `json:"providers,omitempty"`
+	// +kubebuilder:validation:Minimum:=1
+	// The maximum number of master node.
+	MaxMasterNum int `json:"maxMasterNum,omitempty"`
+	// +kubebuilder:validation:Minimum:=0
+	// The maximum number of worker node including the nodes of worker pools.
+	MaxWorkerNum *int `json:"maxWorkerNum,omitempty"`
+	// The allowed versions of kubernetes. A minor version matches all patch versions. Example: v1.22, v1.23.5
+	Versions []string `json:"versions,omitempty"`
+	// The maximum size of VM.
+	InstanceLimits ClusterClaimPolicyInstanceLimits `json:"instanceLimits,omitempty"`
+}
+
+type ClusterClaimPolicyInstanceLimits struct {
+	// The allowed types of VM for aws.
+	AwsInstanceTypes []string `json:"awsInstanceTypes,omitempty"`
+	// The allowed flavors of VM for OpenStack.
+	OpenstackFlavors []string `json:"openstackFlavors,omitempty"`
+	// The maximum number of cpus for vsphere vm.
+	MaxCpuNum int `json:"maxCpuNum,omitempty"`
+	// The maximum memory size for vsphere vm, write as MB without unit.
+	MaxMemSize int `json:"maxMemSize,omitempty"`
+	// The maximum disk size of VM for aws and vsphere, write as GB without unit.
+	MaxDiskSize int `json:"maxDiskSize,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=clusterclaimpolicies,shortName=ccp,scope=Cluster
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// ClusterClaimPolicy is the Schema for the clusterclaimpolicies API
+// 조건을 만족하는 cluster claim을 관리자의 승인 없이 자동으로 승인한다.
+type ClusterClaimPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterClaimPolicySpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+// ClusterClaimPolicyList contains a list of ClusterClaimPolicy
+type ClusterClaimPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterClaimPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterClaimPolicy{}, &ClusterClaimPolicyList{})
+}
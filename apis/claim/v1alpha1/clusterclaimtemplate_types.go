@@ -0,0 +1,100 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterClaimTemplateSpec defines the desired state of ClusterClaimTemplate
+type ClusterClaimTemplateSpec struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum:=AWS;vSphere;OpenStack;Docker
+	// The type of provider.
+	Provider string `json:"provider"`
+	// The name of size used when a claim does not specify size.
+	DefaultSize string `json:"defaultSize,omitempty"`
+	// +listType=map
+	// +listMapKey=name
+	// The named sizes of cluster. Example: small, medium, large
+	Sizes []ClusterClaimTemplateSize `json:"sizes,omitempty"`
+	// Default values of provider Aws Spec.
+	ProviderAwsSpec AwsClaimSpec `json:"providerAwsSpec,omitempty"`
+	// Default values of provider vSphere Spec.
+	ProviderVsphereSpec VsphereClaimSpec `json:"providerVsphereSpec,omitempty"`
+	// Default values of provider OpenStack Spec.
+	ProviderOpenstackSpec OpenstackClaimSpec `json:"providerOpenstackSpec,omitempty"`
+	// Default values of provider Docker Spec.
+	ProviderDockerSpec DockerClaimSpec `json:"providerDockerSpec,omitempty"`
+}
+
+type ClusterClaimTemplateSize struct {
+	// +kubebuilder:validation:Required
+	// The name of size. Example: small
+	Name string `json:"name"`
+	// +kubebuilder:validation:Minimum:=1
+	// The number of master node. Example: 3
+	MasterNum int `json:"masterNum,omitempty"`
+	// +kubebuilder:validation:Minimum:=1
+	// The number of worker node. Example: 2
+	WorkerNum int `json:"workerNum,omitempty"`
+	// The VM spec of the size for aws. Overrides the default values of the template.
+	ProviderAwsSpec AwsClaimSpec `json:"providerAwsSpec,omitempty"`
+	// The VM spec of the size for vSphere. Overrides the default values of the template.
+	ProviderVsphereSpec VsphereClaimSpec `json:"providerVsphereSpec,omitempty"`
+	// The VM spec of the size for OpenStack. Overrides the default values of the template.
+	ProviderOpenstackSpec OpenstackClaimSpec `json:"providerOpenstackSpec,omitempty"`
+	// The node spec of the size for Docker. Overrides the default values of the template.
+	ProviderDockerSpec DockerClaimSpec `json:"providerDockerSpec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=clusterclaimtemplates,shortName=cct,scope=Cluster
+// +kubebuilder:printcolumn:name="Provider",type=string,JSONPath=`.spec.provider`
+// +kubebuilder:printcolumn:name="Default Size",type=string,JSONPath=`.spec.defaultSize`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// ClusterClaimTemplate is the Schema for the clusterclaimtemplates API
+// 관리자가 provider의 기본값과 size별 사양을 정의하면, cluster claim은 templateRef로 참조하여 변경할 값만 입력한다.
+type ClusterClaimTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterClaimTemplateSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+// ClusterClaimTemplateList contains a list of ClusterClaimTemplate
+type ClusterClaimTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterClaimTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterClaimTemplate{}, &ClusterClaimTemplateList{})
+}
+
+// GetSize는 이름으로 size를 찾는다. 이름이 비어있으면 defaultSize를 사용한다.
+func (c *ClusterClaimTemplate) GetSize(name string) *ClusterClaimTemplateSize {
+	if name == "" {
+		name = c.Spec.DefaultSize
+	}
+	for i := range c.Spec.Sizes {
+		if c.Spec.Sizes[i].Name == name {
+			return &c.Spec.Sizes[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterQuotaSpec defines the desired state of ClusterQuota
+type ClusterQuotaSpec struct {
+	// The groups of users to which the quota applies. Matched with the creatorGroups annotation of cluster claim.
+	// Empty means all cluster claims in the namespace.
+	Groups []string `json:"groups,omitempty"`
+	// +kubebuilder:validation:items:Enum:=AWS;vSphere;OpenStack;Docker
+	// The allowed providers. Empty means all providers.
+	Providers []string `json:"providers,omitempty"`
+	// The limits of clusters. Empty field means no limit.
+	Hard ClusterQuotaLimits `json:"hard,omitempty"`
+}
+
+type ClusterQuotaLimits struct {
+	// +kubebuilder:validation:Minimum:=0
+	// The maximum number of clusters.
+	Clusters *int `json:"clusters,omitempty"`
+	// +kubebuilder:validation:Minimum:=0
+	// The maximum number of master nodes of all clusters.
+	MasterNum *int `json:"masterNum,omitempty"`
+	// +kubebuilder:validation:Minimum:=0
+	// The maximum number of worker nodes of all clusters including worker pools.
+	WorkerNum *int `json:"workerNum,omitempty"`
+	// +kubebuilder:validation:Minimum:=0
+	// The maximum number of vCPUs of all nodes. Calculated from vSphere sizing or AWS instance types.
+	CpuNum *int `json:"cpuNum,omitempty"`
+	// +kubebuilder:validation:Minimum:=0
+	// The maximum memory size of all nodes, write as MB without unit. Calculated from vSphere sizing or AWS instance types.
+	MemSize *int `json:"memSize,omitempty"`
+}
+
+// ClusterQuotaUsage는 quota가 적용되는 cluster들의 사용량
+type ClusterQuotaUsage struct {
+	// The number of clusters including claims waiting for approval.
+	Clusters int `json:"clusters"`
+	// The number of master nodes.
+	MasterNum int `json:"masterNum"`
+	// The number of worker nodes.
+	WorkerNum int `json:"workerNum"`
+	// The number of vCPUs.
+	CpuNum int `json:"cpuNum"`
+	// The memory size(MB).
+	MemSize int `json:"memSize"`
+}
+
+// ClusterQuotaStatus defines the observed state of ClusterQuota
+type ClusterQuotaStatus struct {
+	// The current usage of the quota.
+	Used ClusterQuotaUsage `json:"used,omitempty"`
+	// The time when the usage is updated.
+	LastUpdatedTime *metav1.Time `json:"lastUpdatedTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=clusterquotas,shortName=cq,scope=Namespaced
+// +kubebuilder:printcolumn:name="Clusters",type=integer,JSONPath=`.status.used.clusters`
+// +kubebuilder:printcolumn:name="Max Clusters",type=integer,JSONPath=`.spec.hard.clusters`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// ClusterQuota is the Schema for the clusterquotas API
+// namespace에서 생성할 수 있는 cluster의 수와 사양을 제한한다.
+type ClusterQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterQuotaSpec   `json:"spec"`
+	Status ClusterQuotaStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// ClusterQuotaList contains a list of ClusterQuota
+type ClusterQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterQuota{}, &ClusterQuotaList{})
+}
+
+// Add는 사용량을 더한다.
+func (u *ClusterQuotaUsage) Add(o ClusterQuotaUsage) {
+	u.Clusters += o.Clusters
+	u.MasterNum += o.MasterNum
+	u.WorkerNum += o.WorkerNum
+	u.CpuNum += o.CpuNum
+	u.MemSize += o.MemSize
+}
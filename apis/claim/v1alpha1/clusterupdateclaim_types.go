@@ -35,18 +35,29 @@ const (
 type ClusterUpdateClaimReason string
 
 const (
-	ClusterUpdateClaimReasonClusterNotFound   = ClusterUpdateClaimReason("Cluster not found")
-	ClusterUpdateClaimReasonClusterIsDeleting = ClusterUpdateClaimReason("Cluster is deleting")
-	ClusterUpdateClaimReasonAdminApproved     = ClusterUpdateClaimReason("Admin approved")
-	ClusterUpdateClaimReasonAdminAwaiting     = ClusterUpdateClaimReason("Waiting for admin approval")
-	ClusterUpdateClaimReasonConcurruencyError = ClusterUpdateClaimReason("The number of nodes at the time of creation of the clusterupdataclaim differs from the current number of nodes.")
-	ClusterUpdateClaimReasonInvalidCluster    = ClusterUpdateClaimReason("Cluster type is not created type")
+	ClusterUpdateClaimReasonClusterNotFound       = ClusterUpdateClaimReason("Cluster not found")
+	ClusterUpdateClaimReasonClusterIsDeleting     = ClusterUpdateClaimReason("Cluster is deleting")
+	ClusterUpdateClaimReasonAdminApproved         = ClusterUpdateClaimReason("Admin approved")
+	ClusterUpdateClaimReasonAdminAwaiting         = ClusterUpdateClaimReason("Waiting for admin approval")
+	ClusterUpdateClaimReasonConcurruencyError     = ClusterUpdateClaimReason("The number of nodes at the time of creation of the clusterupdataclaim differs from the current number of nodes.")
+	ClusterUpdateClaimReasonInvalidCluster        = ClusterUpdateClaimReason("Cluster type is not created type")
+	ClusterUpdateClaimReasonNoExpiry              = ClusterUpdateClaimReason("Cluster does not have expiry")
+	ClusterUpdateClaimReasonExpiryConcurrency     = ClusterUpdateClaimReason("The expiry time at the time of creation of the clusterupdateclaim differs from the current expiry time.")
+	ClusterUpdateClaimReasonVersionConcurrency    = ClusterUpdateClaimReason("The version at the time of creation of the clusterupdateclaim differs from the current version.")
+	ClusterUpdateClaimReasonClusterPaused         = ClusterUpdateClaimReason("Cluster is paused. The claim is applied after the cluster is resumed")
+	ClusterUpdateClaimReasonProtectionConcurrency = ClusterUpdateClaimReason("The deletion protection at the time of creation of the clusterupdateclaim differs from the current deletion protection.")
 )
 
 type ClusterUpdateType string
 
 const (
 	ClusterUpdateTypeNodeScale = ClusterUpdateType("NodeScale")
+	// cluster의 만료 시각을 연장
+	ClusterUpdateTypeExtension = ClusterUpdateType("Extension")
+	// cluster의 kubernetes version을 upgrade
+	ClusterUpdateTypeUpgrade = ClusterUpdateType("Upgrade")
+	// cluster의 삭제 보호를 설정 또는 해제
+	ClusterUpdateTypeDeletionProtection = ClusterUpdateType("DeletionProtection")
 )
 
 // ClusterUpdateClaimSpec defines the desired state of ClusterUpdateClaim
@@ -54,12 +65,33 @@ type ClusterUpdateClaimSpec struct {
 	// +kubebuilder:validation:Required
 	// Cluster name created using clusterclaim.
 	ClusterName string `json:"clusterName"`
+	// +kubebuilder:validation:Enum:=NodeScale;Extension;Upgrade;DeletionProtection
+	// The type of update. Defaults to NodeScale.
+	UpdateType ClusterUpdateType `json:"updateType,omitempty"`
 	// +kubebuilder:validation:Minimum:=1
 	// The number of master nodes to update.
 	UpdatedMasterNum int `json:"updatedMasterNum,omitempty"`
 	// +kubebuilder:validation:Minimum:=1
 	// The number of worker nodes to update.
 	UpdatedWorkerNum int `json:"updatedWorkerNum,omitempty"`
+	// +listType=map
+	// +listMapKey=name
+	// The worker pools to update. If the pool does not exist, the pool is added to the cluster.
+	// Only replicas can be changed for existing pools.
+	UpdatedWorkerPools []WorkerPoolClaimSpec `json:"updatedWorkerPools,omitempty"`
+	// The duration to extend the expiry time of the cluster. Required for Extension type. Example: 24h
+	ExtendTTL *metav1.Duration `json:"extendTTL,omitempty"`
+	// +kubebuilder:validation:Pattern:=^v[0-9].[0-9]+.[0-9]+
+	// The version of kubernetes to upgrade. Required for Upgrade type. Example: v1.23.5
+	UpdatedVersion string `json:"updatedVersion,omitempty"`
+	// The VM template for the version to upgrade. Required for Upgrade type of vSphere cluster.
+	UpdatedVsphereTemplate string `json:"updatedVsphereTemplate,omitempty"`
+	// Whether to protect the cluster from deletion. Required for DeletionProtection type.
+	// Set false to delete a protected cluster
+	UpdatedDeletionProtection *bool `json:"updatedDeletionProtection,omitempty"`
+	// If true, the changes of the cluster are rendered to status.plan and nothing is changed.
+	// The claim cannot be approved until dryRun is disabled.
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 // ClusterUpdateClaimStatus defines the observed state of ClusterUpdateClaim
@@ -75,16 +107,37 @@ type ClusterUpdateClaimStatus struct {
 	CurrentMasterNum int `json:"currentMasterNum,omitempty"`
 	// The number of current worker node.
 	CurrentWorkerNum int `json:"currentWorkerNum,omitempty"`
+	// The number of current worker node per worker pool.
+	CurrentWorkerPools map[string]int `json:"currentWorkerPools,omitempty"`
+	// The current expiry time of the cluster.
+	CurrentExpiresAt *metav1.Time `json:"currentExpiresAt,omitempty"`
+	// The current version of kubernetes.
+	CurrentVersion string `json:"currentVersion,omitempty"`
+	// The current deletion protection of the cluster.
+	CurrentDeletionProtection bool `json:"currentDeletionProtection,omitempty"`
+
+	// The user who approved or rejected the claim. Set by the webhook.
+	Approver string `json:"approver,omitempty"`
+	// The time when the claim was approved or rejected. Set by the webhook.
+	ApprovalTime *metav1.Time `json:"approvalTime,omitempty"`
+	// The changes rendered by the dry run. Set when spec.dryRun is true.
+	Plan *ClaimPlan `json:"plan,omitempty"`
+
+	// The history of the phase changes. Appended by the webhook when the phase is changed.
+	History []ClaimHistoryRecord `json:"history,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:path=clusterupdateclaims,shortName=cuc,scope=Namespaced
 // +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.clusterName`
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.updateType`
 // +kubebuilder:printcolumn:name="masternum",type=integer,JSONPath=`.spec.updatedMasterNum`
 // +kubebuilder:printcolumn:name="workernum",type=integer,JSONPath=`.spec.updatedWorkerNum`
+// +kubebuilder:printcolumn:name="version",type=string,JSONPath=`.spec.updatedVersion`
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.phase`
 // +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.reason`
+// +kubebuilder:printcolumn:name="Approver",type=string,JSONPath=`.status.approver`,priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // ClusterUpdateClaim is the Schema for the clusterupdateclaims API
 type ClusterUpdateClaim struct {
@@ -164,3 +217,11 @@ func (c *ClusterUpdateClaim) IsPhaseEmpty() bool {
 	}
 	return false
 }
+
+// GetUpdateType은 update type을 반환한다. 지정하지 않은 경우 NodeScale로 처리한다.
+func (c *ClusterUpdateClaim) GetUpdateType() ClusterUpdateType {
+	if c.Spec.UpdateType == "" {
+		return ClusterUpdateTypeNodeScale
+	}
+	return c.Spec.UpdateType
+}
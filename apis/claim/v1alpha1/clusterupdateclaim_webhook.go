@@ -16,8 +16,11 @@ package v1alpha1
 
 import (
 	"fmt"
+	"reflect"
 
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -36,6 +39,14 @@ func (r *ClusterUpdateClaim) SetupWebhookWithManager(mgr ctrl.Manager) error {
 
 var _ webhook.Validator = &ClusterUpdateClaim{}
 
+// ClusterUpdateClaimQuotaValidator는 cluster update claim이 ClusterQuota를 초과하는지 검증하는 함수
+// quota 사용량을 계산하려면 client가 필요하므로 manager를 시작할 때 설정한다.
+var ClusterUpdateClaimQuotaValidator func(cuc *ClusterUpdateClaim) field.ErrorList
+
+// UpgradeVersionValidator는 cluster의 현재 version에서 upgrade할 수 있는 version인지 검증하는 함수
+// cluster manager를 조회해야 하므로 manager를 시작할 때 설정한다.
+var UpgradeVersionValidator func(cuc *ClusterUpdateClaim) field.ErrorList
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *ClusterUpdateClaim) ValidateCreate() error {
 
@@ -46,20 +57,124 @@ func (r *ClusterUpdateClaim) ValidateCreate() error {
 		return fmt.Errorf("r.Spec.UpdatedMasterNum cannot be even")
 	}
 
+	if err := validateWorkerPoolNames(r.Spec.UpdatedWorkerPools, field.NewPath("spec", "updatedWorkerPools")); err != nil {
+		return k8sErrors.NewInvalid(r.GroupVersionKind().GroupKind(), "InvalidSpecWorkerPools", field.ErrorList{err})
+	}
+
+	if errList := r.validateUpdateType(); len(errList) > 0 {
+		return k8sErrors.NewInvalid(r.GroupVersionKind().GroupKind(), "InvalidSpecUpdateType", errList)
+	}
+
+	if r.GetUpdateType() == ClusterUpdateTypeUpgrade && UpgradeVersionValidator != nil {
+		if errList := UpgradeVersionValidator(r); len(errList) > 0 {
+			return k8sErrors.NewInvalid(r.GroupVersionKind().GroupKind(), "InvalidSpecUpdatedVersion", errList)
+		}
+	}
+
+	if ClusterUpdateClaimQuotaValidator != nil {
+		if errList := ClusterUpdateClaimQuotaValidator(r); len(errList) > 0 {
+			return k8sErrors.NewInvalid(r.GroupVersionKind().GroupKind(), "ExceededClusterQuota", errList)
+		}
+	}
+
 	ClusterUpdateClaimWebhookLogger.Info("validate create", "name", r.Name)
 	return nil
 }
 
+// update type에 필요한 field만 사용했는지 검사한다.
+func (r *ClusterUpdateClaim) validateUpdateType() field.ErrorList {
+	errList := field.ErrorList{}
+	switch r.GetUpdateType() {
+	case ClusterUpdateTypeNodeScale:
+		errList = append(errList, r.forbidExtension()...)
+		errList = append(errList, r.forbidUpgrade()...)
+		errList = append(errList, r.forbidDeletionProtection()...)
+	case ClusterUpdateTypeExtension:
+		if r.Spec.ExtendTTL == nil || r.Spec.ExtendTTL.Duration <= 0 {
+			errList = append(errList, field.Required(field.NewPath("spec", "extendTTL"), "must be greater than 0 for Extension type"))
+		}
+		errList = append(errList, r.forbidNodeScale()...)
+		errList = append(errList, r.forbidUpgrade()...)
+		errList = append(errList, r.forbidDeletionProtection()...)
+	case ClusterUpdateTypeUpgrade:
+		// 현재 version과의 비교는 cluster manager를 조회해야 하므로 UpgradeVersionValidator와 controller에서 검사한다.
+		if r.Spec.UpdatedVersion == "" {
+			errList = append(errList, field.Required(field.NewPath("spec", "updatedVersion"), "required for Upgrade type"))
+		}
+		errList = append(errList, r.forbidNodeScale()...)
+		errList = append(errList, r.forbidExtension()...)
+		errList = append(errList, r.forbidDeletionProtection()...)
+	case ClusterUpdateTypeDeletionProtection:
+		if r.Spec.UpdatedDeletionProtection == nil {
+			errList = append(errList, field.Required(field.NewPath("spec", "updatedDeletionProtection"), "required for DeletionProtection type"))
+		}
+		errList = append(errList, r.forbidNodeScale()...)
+		errList = append(errList, r.forbidExtension()...)
+		errList = append(errList, r.forbidUpgrade()...)
+	}
+	return errList
+}
+
+func (r *ClusterUpdateClaim) forbidNodeScale() field.ErrorList {
+	if r.Spec.UpdatedMasterNum != 0 || r.Spec.UpdatedWorkerNum != 0 || len(r.Spec.UpdatedWorkerPools) > 0 {
+		return field.ErrorList{field.Forbidden(field.NewPath("spec"), "can update nodes only for NodeScale type")}
+	}
+	return nil
+}
+
+func (r *ClusterUpdateClaim) forbidExtension() field.ErrorList {
+	if r.Spec.ExtendTTL != nil {
+		return field.ErrorList{field.Forbidden(field.NewPath("spec", "extendTTL"), "can be used only for Extension type")}
+	}
+	return nil
+}
+
+func (r *ClusterUpdateClaim) forbidUpgrade() field.ErrorList {
+	errList := field.ErrorList{}
+	if r.Spec.UpdatedVersion != "" {
+		errList = append(errList, field.Forbidden(field.NewPath("spec", "updatedVersion"), "can be used only for Upgrade type"))
+	}
+	if r.Spec.UpdatedVsphereTemplate != "" {
+		errList = append(errList, field.Forbidden(field.NewPath("spec", "updatedVsphereTemplate"), "can be used only for Upgrade type"))
+	}
+	return errList
+}
+
+func (r *ClusterUpdateClaim) forbidDeletionProtection() field.ErrorList {
+	if r.Spec.UpdatedDeletionProtection != nil {
+		return field.ErrorList{field.Forbidden(field.NewPath("spec", "updatedDeletionProtection"), "can be used only for DeletionProtection type")}
+	}
+	return nil
+}
+
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *ClusterUpdateClaim) ValidateUpdate(old runtime.Object) error {
-	// oc := old.(*ClusterUpdateClaim).DeepCopy()
+	oc := old.(*ClusterUpdateClaim).DeepCopy()
 
-	// masterNum을 짝수로 변경하는 경우
-	masterNum := r.Spec.UpdatedMasterNum
-	if masterNum != 0 && masterNum%2 == 0 {
-		return fmt.Errorf("r.Spec.UpdatedMasterNum cannot be even")
+	if err := validateClaimCreatorUnchanged(r, oc); err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(oc.Spec, r.Spec) {
+		return nil
 	}
 
+	// phase가 정해지기 전에는 controller가 기본값을 채우므로 생성할 때와 같이 검증한다.
+	if oc.Status.Phase == "" {
+		return r.ValidateCreate()
+	}
+
+	// quota를 통과한 변경 내용을 승인 전에 늘리지 못하도록 이후에는 dry run 해제만 허용한다.
+	spec := r.Spec.DeepCopy()
+	spec.DryRun = oc.Spec.DryRun
+	if !reflect.DeepEqual(oc.Spec, *spec) {
+		return fmt.Errorf("cannot modify clusterUpdateClaim except spec.dryRun after it is submitted")
+	}
+	if oc.Spec.DryRun && !r.Spec.DryRun && ClusterUpdateClaimQuotaValidator != nil {
+		if errList := ClusterUpdateClaimQuotaValidator(r); len(errList) > 0 {
+			return k8sErrors.NewInvalid(r.GroupVersionKind().GroupKind(), "ExceededClusterQuota", errList)
+		}
+	}
 	return nil
 }
 
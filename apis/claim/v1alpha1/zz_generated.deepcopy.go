@@ -20,9 +20,31 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovalStageSpec) DeepCopyInto(out *ApprovalStageSpec) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalStageSpec.
+func (in *ApprovalStageSpec) DeepCopy() *ApprovalStageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalStageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AwsClaimSpec) DeepCopyInto(out *AwsClaimSpec) {
 	*out = *in
@@ -38,13 +60,79 @@ func (in *AwsClaimSpec) DeepCopy() *AwsClaimSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClaimApprovalStage) DeepCopyInto(out *ClaimApprovalStage) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ApprovalTime != nil {
+		in, out := &in.ApprovalTime, &out.ApprovalTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClaimApprovalStage.
+func (in *ClaimApprovalStage) DeepCopy() *ClaimApprovalStage {
+	if in == nil {
+		return nil
+	}
+	out := new(ClaimApprovalStage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClaimHistoryRecord) DeepCopyInto(out *ClaimHistoryRecord) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClaimHistoryRecord.
+func (in *ClaimHistoryRecord) DeepCopy() *ClaimHistoryRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(ClaimHistoryRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClaimPlan) DeepCopyInto(out *ClaimPlan) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Summary != nil {
+		in, out := &in.Summary, &out.Summary
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClaimPlan.
+func (in *ClaimPlan) DeepCopy() *ClaimPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(ClaimPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterClaim) DeepCopyInto(out *ClusterClaim) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaim.
@@ -65,6 +153,106 @@ func (in *ClusterClaim) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClaimApprovalPolicy) DeepCopyInto(out *ClusterClaimApprovalPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimApprovalPolicy.
+func (in *ClusterClaimApprovalPolicy) DeepCopy() *ClusterClaimApprovalPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClaimApprovalPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterClaimApprovalPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClaimApprovalPolicyList) DeepCopyInto(out *ClusterClaimApprovalPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterClaimApprovalPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimApprovalPolicyList.
+func (in *ClusterClaimApprovalPolicyList) DeepCopy() *ClusterClaimApprovalPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClaimApprovalPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterClaimApprovalPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClaimApprovalPolicySpec) DeepCopyInto(out *ClusterClaimApprovalPolicySpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]ApprovalStageSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimApprovalPolicySpec.
+func (in *ClusterClaimApprovalPolicySpec) DeepCopy() *ClusterClaimApprovalPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClaimApprovalPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClaimEstimate) DeepCopyInto(out *ClusterClaimEstimate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimEstimate.
+func (in *ClusterClaimEstimate) DeepCopy() *ClusterClaimEstimate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClaimEstimate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterClaimList) DeepCopyInto(out *ClusterClaimList) {
 	*out = *in
@@ -97,11 +285,163 @@ func (in *ClusterClaimList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClaimPolicy) DeepCopyInto(out *ClusterClaimPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimPolicy.
+func (in *ClusterClaimPolicy) DeepCopy() *ClusterClaimPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClaimPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterClaimPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClaimPolicyInstanceLimits) DeepCopyInto(out *ClusterClaimPolicyInstanceLimits) {
+	*out = *in
+	if in.AwsInstanceTypes != nil {
+		in, out := &in.AwsInstanceTypes, &out.AwsInstanceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OpenstackFlavors != nil {
+		in, out := &in.OpenstackFlavors, &out.OpenstackFlavors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimPolicyInstanceLimits.
+func (in *ClusterClaimPolicyInstanceLimits) DeepCopy() *ClusterClaimPolicyInstanceLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClaimPolicyInstanceLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClaimPolicyList) DeepCopyInto(out *ClusterClaimPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterClaimPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimPolicyList.
+func (in *ClusterClaimPolicyList) DeepCopy() *ClusterClaimPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClaimPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterClaimPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClaimPolicySpec) DeepCopyInto(out *ClusterClaimPolicySpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxWorkerNum != nil {
+		in, out := &in.MaxWorkerNum, &out.MaxWorkerNum
+		*out = new(int)
+		**out = **in
+	}
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.InstanceLimits.DeepCopyInto(&out.InstanceLimits)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimPolicySpec.
+func (in *ClusterClaimPolicySpec) DeepCopy() *ClusterClaimPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClaimPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterClaimSpec) DeepCopyInto(out *ClusterClaimSpec) {
 	*out = *in
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(ClusterClaimTemplateReference)
+		**out = **in
+	}
+	if in.WorkerPools != nil {
+		in, out := &in.WorkerPools, &out.WorkerPools
+		*out = make([]WorkerPoolClaimSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.ProviderAwsSpec = in.ProviderAwsSpec
 	out.ProviderVsphereSpec = in.ProviderVsphereSpec
+	out.ProviderOpenstackSpec = in.ProviderOpenstackSpec
+	in.ProviderDockerSpec.DeepCopyInto(&out.ProviderDockerSpec)
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimSpec.
@@ -117,6 +457,34 @@ func (in *ClusterClaimSpec) DeepCopy() *ClusterClaimSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterClaimStatus) DeepCopyInto(out *ClusterClaimStatus) {
 	*out = *in
+	if in.ApprovalTime != nil {
+		in, out := &in.ApprovalTime, &out.ApprovalTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ApprovalStages != nil {
+		in, out := &in.ApprovalStages, &out.ApprovalStages
+		*out = make([]ClaimApprovalStage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Estimate != nil {
+		in, out := &in.Estimate, &out.Estimate
+		*out = new(ClusterClaimEstimate)
+		**out = **in
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(ClaimPlan)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]ClaimHistoryRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimStatus.
@@ -129,13 +497,291 @@ func (in *ClusterClaimStatus) DeepCopy() *ClusterClaimStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClaimTemplate) DeepCopyInto(out *ClusterClaimTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimTemplate.
+func (in *ClusterClaimTemplate) DeepCopy() *ClusterClaimTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClaimTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterClaimTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClaimTemplateList) DeepCopyInto(out *ClusterClaimTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterClaimTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimTemplateList.
+func (in *ClusterClaimTemplateList) DeepCopy() *ClusterClaimTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClaimTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterClaimTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClaimTemplateReference) DeepCopyInto(out *ClusterClaimTemplateReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimTemplateReference.
+func (in *ClusterClaimTemplateReference) DeepCopy() *ClusterClaimTemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClaimTemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClaimTemplateSize) DeepCopyInto(out *ClusterClaimTemplateSize) {
+	*out = *in
+	out.ProviderAwsSpec = in.ProviderAwsSpec
+	out.ProviderVsphereSpec = in.ProviderVsphereSpec
+	out.ProviderOpenstackSpec = in.ProviderOpenstackSpec
+	in.ProviderDockerSpec.DeepCopyInto(&out.ProviderDockerSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimTemplateSize.
+func (in *ClusterClaimTemplateSize) DeepCopy() *ClusterClaimTemplateSize {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClaimTemplateSize)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClaimTemplateSpec) DeepCopyInto(out *ClusterClaimTemplateSpec) {
+	*out = *in
+	if in.Sizes != nil {
+		in, out := &in.Sizes, &out.Sizes
+		*out = make([]ClusterClaimTemplateSize, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.ProviderAwsSpec = in.ProviderAwsSpec
+	out.ProviderVsphereSpec = in.ProviderVsphereSpec
+	out.ProviderOpenstackSpec = in.ProviderOpenstackSpec
+	in.ProviderDockerSpec.DeepCopyInto(&out.ProviderDockerSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimTemplateSpec.
+func (in *ClusterClaimTemplateSpec) DeepCopy() *ClusterClaimTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClaimTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterQuota) DeepCopyInto(out *ClusterQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuota.
+func (in *ClusterQuota) DeepCopy() *ClusterQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterQuotaLimits) DeepCopyInto(out *ClusterQuotaLimits) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = new(int)
+		**out = **in
+	}
+	if in.MasterNum != nil {
+		in, out := &in.MasterNum, &out.MasterNum
+		*out = new(int)
+		**out = **in
+	}
+	if in.WorkerNum != nil {
+		in, out := &in.WorkerNum, &out.WorkerNum
+		*out = new(int)
+		**out = **in
+	}
+	if in.CpuNum != nil {
+		in, out := &in.CpuNum, &out.CpuNum
+		*out = new(int)
+		**out = **in
+	}
+	if in.MemSize != nil {
+		in, out := &in.MemSize, &out.MemSize
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuotaLimits.
+func (in *ClusterQuotaLimits) DeepCopy() *ClusterQuotaLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterQuotaLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterQuotaList) DeepCopyInto(out *ClusterQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuotaList.
+func (in *ClusterQuotaList) DeepCopy() *ClusterQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterQuotaSpec) DeepCopyInto(out *ClusterQuotaSpec) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Hard.DeepCopyInto(&out.Hard)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuotaSpec.
+func (in *ClusterQuotaSpec) DeepCopy() *ClusterQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterQuotaStatus) DeepCopyInto(out *ClusterQuotaStatus) {
+	*out = *in
+	out.Used = in.Used
+	if in.LastUpdatedTime != nil {
+		in, out := &in.LastUpdatedTime, &out.LastUpdatedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuotaStatus.
+func (in *ClusterQuotaStatus) DeepCopy() *ClusterQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterQuotaUsage) DeepCopyInto(out *ClusterQuotaUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuotaUsage.
+func (in *ClusterQuotaUsage) DeepCopy() *ClusterQuotaUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterQuotaUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterUpdateClaim) DeepCopyInto(out *ClusterUpdateClaim) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpdateClaim.
@@ -191,6 +837,23 @@ func (in *ClusterUpdateClaimList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterUpdateClaimSpec) DeepCopyInto(out *ClusterUpdateClaimSpec) {
 	*out = *in
+	if in.UpdatedWorkerPools != nil {
+		in, out := &in.UpdatedWorkerPools, &out.UpdatedWorkerPools
+		*out = make([]WorkerPoolClaimSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtendTTL != nil {
+		in, out := &in.ExtendTTL, &out.ExtendTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.UpdatedDeletionProtection != nil {
+		in, out := &in.UpdatedDeletionProtection, &out.UpdatedDeletionProtection
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpdateClaimSpec.
@@ -206,6 +869,33 @@ func (in *ClusterUpdateClaimSpec) DeepCopy() *ClusterUpdateClaimSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterUpdateClaimStatus) DeepCopyInto(out *ClusterUpdateClaimStatus) {
 	*out = *in
+	if in.CurrentWorkerPools != nil {
+		in, out := &in.CurrentWorkerPools, &out.CurrentWorkerPools
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CurrentExpiresAt != nil {
+		in, out := &in.CurrentExpiresAt, &out.CurrentExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ApprovalTime != nil {
+		in, out := &in.ApprovalTime, &out.ApprovalTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(ClaimPlan)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]ClaimHistoryRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpdateClaimStatus.
@@ -218,6 +908,56 @@ func (in *ClusterUpdateClaimStatus) DeepCopy() *ClusterUpdateClaimStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerClaimSpec) DeepCopyInto(out *DockerClaimSpec) {
+	*out = *in
+	if in.ExtraMounts != nil {
+		in, out := &in.ExtraMounts, &out.ExtraMounts
+		*out = make([]DockerMountClaimSpec, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerClaimSpec.
+func (in *DockerClaimSpec) DeepCopy() *DockerClaimSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerClaimSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerMountClaimSpec) DeepCopyInto(out *DockerMountClaimSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerMountClaimSpec.
+func (in *DockerMountClaimSpec) DeepCopy() *DockerMountClaimSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerMountClaimSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenstackClaimSpec) DeepCopyInto(out *OpenstackClaimSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenstackClaimSpec.
+func (in *OpenstackClaimSpec) DeepCopy() *OpenstackClaimSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenstackClaimSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VsphereClaimSpec) DeepCopyInto(out *VsphereClaimSpec) {
 	*out = *in
@@ -232,3 +972,32 @@ func (in *VsphereClaimSpec) DeepCopy() *VsphereClaimSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerPoolClaimSpec) DeepCopyInto(out *WorkerPoolClaimSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]corev1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerPoolClaimSpec.
+func (in *WorkerPoolClaimSpec) DeepCopy() *WorkerPoolClaimSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerPoolClaimSpec)
+	in.DeepCopyInto(out)
+	return out
+}
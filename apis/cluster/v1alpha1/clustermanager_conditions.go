@@ -0,0 +1,156 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// condition types
+const (
+	// 모든 단계가 완료되어 클러스터를 사용할 수 있는 상태
+	ConditionTypeReady = "Ready"
+	// template instance를 통해 infra(vm, lb 등)가 생성된 상태
+	ConditionTypeInfrastructureReady = "InfrastructureReady"
+	// control plane이 초기화되어 api server에 접근 가능한 상태
+	ConditionTypeControlPlaneReady = "ControlPlaneReady"
+	// ArgoCD에 cluster가 등록된 상태
+	ConditionTypeArgoReady = "ArgoReady"
+	// single cluster의 api gateway로 연결되는 service가 생성된 상태
+	ConditionTypeGatewayReady = "GatewayReady"
+	// HyperAuth client, group 등이 생성된 상태
+	ConditionTypeAuthClientReady = "AuthClientReady"
+	// traefik ingress, middleware 등이 생성된 상태
+	ConditionTypeTraefikReady = "TraefikReady"
+	// 클러스터가 업그레이드 중인 상태
+	ConditionTypeUpgrading = "Upgrading"
+	// 클러스터가 스케일링 중인 상태
+	ConditionTypeScaling = "Scaling"
+	// health prober가 클러스터의 api server에 접근 가능한 상태
+	ConditionTypeHealthy = "Healthy"
+	// 승인된 upgrade, scaling이 maintenance window를 기다리는 상태
+	ConditionTypeMaintenancePending = "MaintenancePending"
+	// upgrade 전에 remote cluster의 node, PodDisruptionBudget, deprecated api 검사를 통과한 상태
+	ConditionTypeUpgradePreflightPassed = "UpgradePreflightPassed"
+	// spec.paused에 의해 operator가 클러스터를 reconcile하지 않는 상태
+	ConditionTypePaused = "Paused"
+)
+
+// condition reasons
+const (
+	ConditionReasonMigrated = "MigratedFromStatus"
+	// deadline을 계산하기 위해 condition이 없는 단계의 시작 시점을 기록한다.
+	ConditionReasonPhaseStarted = "PhaseStarted"
+
+	ConditionReasonTemplateInstanceCreated = "TemplateInstanceCreated"
+	ConditionReasonWaitingForEndpoint      = "WaitingForEndpoint"
+	ConditionReasonInfrastructureReady     = "InfrastructureProvisioned"
+
+	ConditionReasonWaitingForControlPlane  = "WaitingForControlPlane"
+	ConditionReasonControlPlaneInitialized = "ControlPlaneInitialized"
+	ConditionReasonKubeconfigNotFound      = "KubeconfigNotFound"
+	ConditionReasonRemoteClusterNotReady   = "RemoteClusterNotReady"
+
+	ConditionReasonArgoTokenNotReady = "ServiceAccountTokenNotReady"
+	ConditionReasonArgoRegistered    = "ClusterRegistered"
+
+	ConditionReasonGatewayServiceNotFound = "GatewayServiceNotFound"
+	ConditionReasonGatewayServiceNotReady = "GatewayServiceNotReady"
+	ConditionReasonGatewayCreated         = "GatewayServiceCreated"
+
+	ConditionReasonAuthClientSkipped = "OidcClientSetDisabled"
+	ConditionReasonAuthClientFailed  = "HyperAuthRequestFailed"
+	ConditionReasonAuthClientCreated = "ClientsCreated"
+
+	ConditionReasonTraefikFailed   = "TraefikResourceFailed"
+	ConditionReasonTraefikCreated  = "TraefikResourcesCreated"
+	ConditionReasonSubresourceLost = "SubresourceDeleted"
+
+	ConditionReasonWaitingForSubresources = "WaitingForSubresources"
+	ConditionReasonClusterReady           = "ClusterReady"
+	ConditionReasonClusterUnhealthy       = "ClusterUnhealthy"
+	ConditionReasonDeleting               = "Deleting"
+
+	ConditionReasonWaitingForUpgradeTemplate = "WaitingForUpgradeTemplate"
+	ConditionReasonUpgradingControlPlane     = "UpgradingControlPlane"
+	ConditionReasonUpgradingWorker           = "UpgradingWorker"
+	ConditionReasonUpgradeCompleted          = "UpgradeCompleted"
+	ConditionReasonWaitingForPreflightChecks = "WaitingForPreflightChecks"
+	ConditionReasonPreflightChecksFailed     = "PreflightChecksFailed"
+	ConditionReasonPreflightChecksPassed     = "PreflightChecksPassed"
+
+	ConditionReasonScalingControlPlane = "ScalingControlPlane"
+	ConditionReasonScalingWorker       = "ScalingWorker"
+	ConditionReasonScalingCompleted    = "ScalingCompleted"
+
+	ConditionReasonClusterResponding    = "ClusterResponding"
+	ConditionReasonClusterNotResponding = "ClusterNotResponding"
+
+	ConditionReasonWaitingForMaintenanceWindow = "WaitingForMaintenanceWindow"
+	ConditionReasonInvalidMaintenanceWindow    = "InvalidMaintenanceWindow"
+	ConditionReasonMaintenanceStarted          = "MaintenanceStarted"
+	ConditionReasonNoPendingChanges            = "NoPendingChanges"
+
+	ConditionReasonPausedBySpec = "PausedBySpec"
+	ConditionReasonResumed      = "Resumed"
+)
+
+// SetCondition은 condition을 추가하거나 갱신한다.
+// status가 바뀐 경우에만 lastTransitionTime이 갱신된다.
+func (c *ClusterManager) SetCondition(conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&c.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: c.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+func (c *ClusterManager) MarkConditionTrue(conditionType, reason, message string) {
+	c.SetCondition(conditionType, metav1.ConditionTrue, reason, message)
+}
+
+func (c *ClusterManager) MarkConditionFalse(conditionType, reason, message string) {
+	c.SetCondition(conditionType, metav1.ConditionFalse, reason, message)
+}
+
+func (c *ClusterManager) GetCondition(conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(c.Status.Conditions, conditionType)
+}
+
+func (c *ClusterManager) IsConditionTrue(conditionType string) bool {
+	return meta.IsStatusConditionTrue(c.Status.Conditions, conditionType)
+}
+
+// MigrateConditions는 condition이 도입되기 전에 생성된 cluster manager의
+// boolean status 값을 condition으로 옮긴다.
+func (c *ClusterManager) MigrateConditions() {
+	flags := map[string]bool{
+		ConditionTypeInfrastructureReady: c.GetClusterType() == ClusterTypeCreated &&
+			c.Annotations[AnnotationKeyClmApiserver] != "",
+		ConditionTypeControlPlaneReady: c.Status.ControlPlaneReady,
+		ConditionTypeArgoReady:         c.Status.ArgoReady,
+		ConditionTypeGatewayReady:      c.Status.GatewayReady,
+		ConditionTypeAuthClientReady:   c.Status.AuthClientReady,
+		ConditionTypeTraefikReady:      c.Status.TraefikReady,
+	}
+	for conditionType, ready := range flags {
+		if ready && c.GetCondition(conditionType) == nil {
+			c.MarkConditionTrue(conditionType, ConditionReasonMigrated, "")
+		}
+	}
+}
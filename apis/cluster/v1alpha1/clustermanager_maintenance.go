@@ -0,0 +1,59 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron"
+)
+
+// Validate는 schedule, duration, time zone이 올바른지 검사한다.
+func (w *MaintenanceWindow) Validate() error {
+	_, _, err := w.parse()
+	return err
+}
+
+// Next는 now가 window 안에 있는지 확인한다.
+// window 안이면 현재 window가 끝나는 시각을, 밖이면 다음 window가 시작하는 시각을 반환한다.
+func (w *MaintenanceWindow) Next(now time.Time) (bool, time.Time, error) {
+	schedule, loc, err := w.parse()
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	// duration 이전 시각 이후로 처음 시작한 window가 now 이전에 시작했으면 window 안이다.
+	start := schedule.Next(now.In(loc).Add(-w.Duration.Duration))
+	if start.After(now) {
+		return false, start, nil
+	}
+	return true, start.Add(w.Duration.Duration), nil
+}
+
+func (w *MaintenanceWindow) parse() (cron.Schedule, *time.Location, error) {
+	schedule, err := cron.ParseStandard(w.Schedule)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid schedule [%s]: %v", w.Schedule, err)
+	}
+	if w.Duration.Duration <= 0 {
+		return nil, nil, fmt.Errorf("duration must be greater than 0")
+	}
+	loc, err := time.LoadLocation(w.TimeZone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid time zone [%s]: %v", w.TimeZone, err)
+	}
+	return schedule, loc, nil
+}
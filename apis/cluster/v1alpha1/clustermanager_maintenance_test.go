@@ -0,0 +1,147 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMaintenanceWindowNext(t *testing.T) {
+	utc := func(value string) time.Time {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+	window := func(schedule string, duration time.Duration, timeZone string) *MaintenanceWindow {
+		return &MaintenanceWindow{Schedule: schedule, Duration: metav1.Duration{Duration: duration}, TimeZone: timeZone}
+	}
+
+	tests := []struct {
+		name     string
+		window   *MaintenanceWindow
+		now      time.Time
+		wantOpen bool
+		wantTime time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "before window",
+			window:   window("0 2 * * *", 2*time.Hour, ""),
+			now:      utc("2022-01-01T01:00:00Z"),
+			wantOpen: false,
+			wantTime: utc("2022-01-01T02:00:00Z"),
+		},
+		{
+			name:     "window already open",
+			window:   window("0 2 * * *", 2*time.Hour, ""),
+			now:      utc("2022-01-01T02:30:00Z"),
+			wantOpen: true,
+			wantTime: utc("2022-01-01T04:00:00Z"),
+		},
+		{
+			name:     "start of window is open",
+			window:   window("0 2 * * *", 2*time.Hour, ""),
+			now:      utc("2022-01-01T02:00:00Z"),
+			wantOpen: true,
+			wantTime: utc("2022-01-01T04:00:00Z"),
+		},
+		{
+			name:     "end of window is closed",
+			window:   window("0 2 * * *", 2*time.Hour, ""),
+			now:      utc("2022-01-01T04:00:00Z"),
+			wantOpen: false,
+			wantTime: utc("2022-01-02T02:00:00Z"),
+		},
+		{
+			name:     "weekly window",
+			window:   window("0 2 * * 6", 4*time.Hour, "UTC"),
+			now:      utc("2022-01-03T10:00:00Z"),
+			wantOpen: false,
+			wantTime: utc("2022-01-08T02:00:00Z"),
+		},
+		{
+			name:     "time zone before window",
+			window:   window("0 2 * * *", 2*time.Hour, "Asia/Seoul"),
+			now:      utc("2022-01-01T16:30:00Z"),
+			wantOpen: false,
+			wantTime: utc("2022-01-01T17:00:00Z"),
+		},
+		{
+			name:     "time zone window open",
+			window:   window("0 2 * * *", 2*time.Hour, "Asia/Seoul"),
+			now:      utc("2022-01-01T17:30:00Z"),
+			wantOpen: true,
+			wantTime: utc("2022-01-01T19:00:00Z"),
+		},
+		{
+			name:     "window spanning midnight is open after midnight",
+			window:   window("0 23 * * *", 3*time.Hour, ""),
+			now:      utc("2022-01-02T01:00:00Z"),
+			wantOpen: true,
+			wantTime: utc("2022-01-02T02:00:00Z"),
+		},
+		{
+			name:     "window spanning midnight is closed after end",
+			window:   window("0 23 * * *", 3*time.Hour, ""),
+			now:      utc("2022-01-02T02:30:00Z"),
+			wantOpen: false,
+			wantTime: utc("2022-01-02T23:00:00Z"),
+		},
+		{
+			name:     "window spanning midnight in time zone",
+			window:   window("0 23 * * *", 3*time.Hour, "Asia/Seoul"),
+			now:      utc("2022-01-01T15:30:00Z"),
+			wantOpen: true,
+			wantTime: utc("2022-01-01T17:00:00Z"),
+		},
+		{
+			name:    "invalid schedule",
+			window:  window("every day", 2*time.Hour, ""),
+			now:     utc("2022-01-01T00:00:00Z"),
+			wantErr: true,
+		},
+		{
+			name:    "invalid duration",
+			window:  window("0 2 * * *", 0, ""),
+			now:     utc("2022-01-01T00:00:00Z"),
+			wantErr: true,
+		},
+		{
+			name:    "invalid time zone",
+			window:  window("0 2 * * *", 2*time.Hour, "Asia/Nowhere"),
+			now:     utc("2022-01-01T00:00:00Z"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			open, next, err := tt.window.Next(tt.now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Next() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if open != tt.wantOpen || !next.Equal(tt.wantTime) {
+				t.Errorf("Next() = (%v, %v), want (%v, %v)", open, next.UTC(), tt.wantOpen, tt.wantTime)
+			}
+		})
+	}
+}
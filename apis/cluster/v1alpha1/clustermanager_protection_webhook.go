@@ -0,0 +1,81 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	admissionV1 "k8s.io/api/admission/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// OperatorUsername은 operator의 service account 이름 (system:serviceaccount:{namespace}:{name})
+// 삭제 보호는 승인된 cluster update claim으로 operator만 해제할 수 있다. manager를 시작할 때 설정한다.
+var OperatorUsername string
+
+const deletionProtectionWebhookPath = "/validate-cluster-tmax-io-v1alpha1-clustermanager-protection"
+
+// SetupDeletionProtectionWebhookWithManager는 삭제 보호 해제를 검증하는 webhook을 등록한다.
+// webhook.Validator로는 요청한 사용자 정보를 알 수 없으므로 admission handler를 직접 등록한다.
+func SetupDeletionProtectionWebhookWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(deletionProtectionWebhookPath, &webhook.Admission{Handler: &DeletionProtectionValidator{}})
+}
+
+// +kubebuilder:webhook:path=/validate-cluster-tmax-io-v1alpha1-clustermanager-protection,mutating=false,failurePolicy=fail,groups=cluster.tmax.io,resources=clustermanagers,verbs=update,versions=v1alpha1,name=validation.webhook.clustermanager.protection,admissionReviewVersions=v1beta1;v1,sideEffects=None
+
+// DeletionProtectionValidator는 operator가 아닌 사용자가 cluster manager의 삭제 보호를 해제하지 못하도록 한다.
+// +kubebuilder:object:generate=false
+type DeletionProtectionValidator struct {
+	decoder *admission.Decoder
+}
+
+var _ admission.Handler = &DeletionProtectionValidator{}
+var _ admission.DecoderInjector = &DeletionProtectionValidator{}
+
+func (v *DeletionProtectionValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
+
+func (v *DeletionProtectionValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionV1.Update {
+		return admission.Allowed("")
+	}
+
+	clm, old := &ClusterManager{}, &ClusterManager{}
+	if err := v.decoder.Decode(req, clm); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if err := v.decoder.DecodeRaw(req.OldObject, old); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	specUnprotected := old.Spec.DeletionProtection && !clm.Spec.DeletionProtection
+	annotationUnprotected := old.Annotations[AnnotationKeyClmDeletionProtection] == "true" &&
+		clm.Annotations[AnnotationKeyClmDeletionProtection] != "true"
+	if !specUnprotected && !annotationUnprotected {
+		return admission.Allowed("")
+	}
+	if OperatorUsername != "" && req.UserInfo.Username == OperatorUsername {
+		return admission.Allowed("")
+	}
+	return admission.Denied(fmt.Sprintf("cluster manager %s is protected from deletion. "+
+		"Disable spec.deletionProtection and annotation %s by ClusterUpdateClaim of DeletionProtection type",
+		clm.Name, AnnotationKeyClmDeletionProtection))
+}
@@ -15,6 +15,9 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"encoding/json"
+	"hash/fnv"
+	"strconv"
 	"strings"
 
 	coreV1 "k8s.io/api/core/v1"
@@ -22,19 +25,48 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 )
 
-// type NodeInfo struct {
-// 	Name      string         `json:"name,omitempty"`
-// 	Ip        string         `json:"ip,omitempty"`
-// 	IsMaster  bool           `json:"isMaster,omitempty"`
-// 	Resources []ResourceType `json:"resources,omitempty"`
-// }
+type NodeInfo struct {
+	Name string `json:"name,omitempty"`
+	// master 또는 worker
+	Role           string         `json:"role,omitempty"`
+	Ip             string         `json:"ip,omitempty"`
+	KubeletVersion string         `json:"kubeletVersion,omitempty"`
+	OsImage        string         `json:"osImage,omitempty"`
+	Ready          bool           `json:"ready,omitempty"`
+	Resources      []ResourceType `json:"resources,omitempty"`
+}
 
 type ResourceType struct {
-	Type     string `json:"type,omitempty"`
-	Capacity string `json:"capacity,omitempty"`
-	Usage    string `json:"usage,omitempty"`
+	// cpu, memory, pods
+	Type        string `json:"type,omitempty"`
+	Capacity    string `json:"capacity,omitempty"`
+	Allocatable string `json:"allocatable,omitempty"`
+	// metrics-server가 설치되어 있지 않은 경우에는 비어있다.
+	Usage string `json:"usage,omitempty"`
+}
+
+// MaintenanceWindow는 upgrade, scaling을 수행할 수 있는 시간대
+type MaintenanceWindow struct {
+	// +kubebuilder:validation:Required
+	// The start time of window in standard 5-field cron format. Example: "0 2 * * 6" (every Saturday 02:00)
+	Schedule string `json:"schedule"`
+	// +kubebuilder:validation:Required
+	// The length of window. Example: 4h
+	Duration metav1.Duration `json:"duration"`
+	// The time zone of schedule in IANA format. Default is UTC. Example: Asia/Seoul
+	TimeZone string `json:"timeZone,omitempty"`
 }
 
+// ClusterDeletionPolicy는 cluster manager를 삭제할 때 cluster의 처리 방법
+type ClusterDeletionPolicy string
+
+const (
+	// cluster와 infra를 함께 삭제한다.
+	ClusterDeletionPolicyDelete = ClusterDeletionPolicy("Delete")
+	// cluster manager만 삭제하고 cluster와 infra는 유지한다.
+	ClusterDeletionPolicyDetach = ClusterDeletionPolicy("Detach")
+)
+
 // ClusterManagerSpec defines the desired state of ClusterManager
 type ClusterManagerSpec struct {
 	// +kubebuilder:validation:Required
@@ -49,12 +81,66 @@ type ClusterManagerSpec struct {
 	// +kubebuilder:validation:Required
 	// The number of worker node
 	WorkerNum int `json:"workerNum"`
+	// +listType=map
+	// +listMapKey=name
+	// The additional worker pools. The default pool(md-0) is created with workerNum
+	WorkerPools []WorkerPool `json:"workerPools,omitempty"`
+	// The time when the cluster expires. The cluster manager is deleted when it expires
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+	// The window when version upgrade and node scaling are applied.
+	// Changes made outside of the window are pending until the next window. Empty means changes are applied immediately
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+	// Paused stops the operator from reconciling the cluster and is propagated to the CAPI Cluster.
+	// Used during manual incident work on the cluster
+	Paused bool `json:"paused,omitempty"`
+	// +kubebuilder:validation:Enum:=Delete;Detach
+	// The policy when the cluster manager is deleted. Default is Delete which destroys the cluster and infrastructure.
+	// Detach removes HyperAuth clients, ArgoCD secret and Traefik resources, and keeps the CAPI Cluster and infrastructure running
+	DeletionPolicy ClusterDeletionPolicy `json:"deletionPolicy,omitempty"`
+	// DeletionProtection prevents the cluster manager from being deleted. The cluster is not deleted even if it expires.
+	// To delete the cluster, disable it first by ClusterUpdateClaim of DeletionProtection type
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
 	// The version of kubernetes
 	// KubernetesVersion string `json:"kubernetesVersion"`
 	// The owner of cluster
 	// Owner string `json:"owner"`
 }
 
+// WorkerPool defines
+type WorkerPool struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern:=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// The name of worker pool. MachineDeployment is created as {cluster name}-{pool name}
+	Name string `json:"name"`
+	// +kubebuilder:validation:Minimum:=0
+	// The number of worker node in the pool
+	Replicas int `json:"replicas"`
+	// The type of VM for aws worker node
+	InstanceType string `json:"instanceType,omitempty"`
+	// The disk size of VM. Example: 20
+	DiskSize int `json:"diskSize,omitempty"`
+	// The number of cpus for vsphere vm
+	CpuNum int `json:"cpuNum,omitempty"`
+	// The memory size for vsphere vm
+	MemSize int `json:"memSize,omitempty"`
+	// The labels to be added to the nodes in the pool
+	Labels map[string]string `json:"labels,omitempty"`
+	// The taints to be added to the nodes in the pool
+	Taints []coreV1.Taint `json:"taints,omitempty"`
+}
+
+// WorkerPoolStatus defines
+type WorkerPoolStatus struct {
+	// The name of worker pool
+	Name string `json:"name"`
+	// The number of worker node applied to the MachineDeployment
+	Replicas int `json:"replicas,omitempty"`
+	// The number of ready worker node
+	ReadyReplicas int `json:"readyReplicas,omitempty"`
+	// The hash of the worker pool spec except replicas applied to the MachineDeployment
+	TemplateHash string `json:"templateHash,omitempty"`
+}
+
 // ProviderAwsSpec defines
 type ProviderAwsSpec struct {
 	// The region where VM is working
@@ -107,26 +193,84 @@ type ProviderVsphereSpec struct {
 	VMPassword string `json:"vmPassword,omitempty"`
 }
 
+// ProviderOpenstackSpec defines
+type ProviderOpenstackSpec struct {
+	// The name of secret which has clouds.yaml for authentication
+	CloudsSecretName string `json:"cloudsSecretName,omitempty"`
+	// The name of cloud in clouds.yaml
+	CloudName string `json:"cloudName,omitempty"`
+	// The flavor of VM for master node
+	MasterFlavor string `json:"masterFlavor,omitempty"`
+	// The flavor of VM for worker node
+	WorkerFlavor string `json:"workerFlavor,omitempty"`
+	// The name of image to create VM
+	Image string `json:"image,omitempty"`
+	// The name of existing network for nodes
+	Network string `json:"network,omitempty"`
+	// The cidr block of the network created for nodes
+	NodeCidr string `json:"nodeCidr,omitempty"`
+	// The id of external network to allocate floating IP
+	FloatingIpPool string `json:"floatingIpPool,omitempty"`
+	// The name of ssh key pair to access VM
+	SshKey string `json:"sshKey,omitempty"`
+}
+
+// ProviderDockerSpec defines
+type ProviderDockerSpec struct {
+	// The repository of kind node image
+	NodeImageRepository string `json:"nodeImageRepository,omitempty"`
+	// The host directories mounted to node containers
+	ExtraMounts []DockerMount `json:"extraMounts,omitempty"`
+}
+
+type DockerMount struct {
+	HostPath      string `json:"hostPath"`
+	ContainerPath string `json:"containerPath"`
+	ReadOnly      bool   `json:"readOnly,omitempty"`
+}
+
 // ClusterManagerStatus defines the observed state of ClusterManager
 type ClusterManagerStatus struct {
-	Provider              string                  `json:"provider,omitempty"`
-	Version               string                  `json:"version,omitempty"`
-	Ready                 bool                    `json:"ready,omitempty"`
-	ControlPlaneReady     bool                    `json:"controlPlaneReady,omitempty"`
-	MasterRun             int                     `json:"masterRun,omitempty"`
-	WorkerRun             int                     `json:"workerRun,omitempty"`
-	MasterNum             int                     `json:"masterNum,omitempty"`
-	WorkerNum             int                     `json:"workerNum,omitempty"`
-	NodeInfo              []coreV1.NodeSystemInfo `json:"nodeInfo,omitempty"`
-	Phase                 ClusterManagerPhase     `json:"phase,omitempty"`
-	ControlPlaneEndpoint  string                  `json:"controlPlaneEndpoint,omitempty"`
-	ArgoReady             bool                    `json:"argoReady,omitempty"`
-	TraefikReady          bool                    `json:"traefikReady,omitempty"`
-	GatewayReady          bool                    `json:"gatewayReady,omitempty"`
-	GatewayReadyMigration bool                    `json:"gatewayReadyMigration,omitempty"`
-	AuthClientReady       bool                    `json:"authClientReady,omitempty"`
-	OpenSearchReady       bool                    `json:"openSearchReady,omitempty"`
-	ApplicationLink       string                  `json:"applicationLink,omitempty"`
+	Provider              string              `json:"provider,omitempty"`
+	Version               string              `json:"version,omitempty"`
+	Ready                 bool                `json:"ready,omitempty"`
+	ControlPlaneReady     bool                `json:"controlPlaneReady,omitempty"`
+	MasterRun             int                 `json:"masterRun,omitempty"`
+	WorkerRun             int                 `json:"workerRun,omitempty"`
+	MasterNum             int                 `json:"masterNum,omitempty"`
+	WorkerNum             int                 `json:"workerNum,omitempty"`
+	NodeInfo              []NodeInfo          `json:"nodeInfo,omitempty"`
+	Phase                 ClusterManagerPhase `json:"phase,omitempty"`
+	ControlPlaneEndpoint  string              `json:"controlPlaneEndpoint,omitempty"`
+	ArgoReady             bool                `json:"argoReady,omitempty"`
+	TraefikReady          bool                `json:"traefikReady,omitempty"`
+	GatewayReady          bool                `json:"gatewayReady,omitempty"`
+	GatewayReadyMigration bool                `json:"gatewayReadyMigration,omitempty"`
+	AuthClientReady       bool                `json:"authClientReady,omitempty"`
+	OpenSearchReady       bool                `json:"openSearchReady,omitempty"`
+	ApplicationLink       string              `json:"applicationLink,omitempty"`
+
+	// worker pool별 상태
+	WorkerPools []WorkerPoolStatus `json:"workerPools,omitempty"`
+
+	// 전체 node의 resource 합계
+	Resources []ResourceType `json:"resources,omitempty"`
+	// nodeInfo, resources를 마지막으로 수집한 시간
+	LastCollectedTime *metav1.Time `json:"lastCollectedTime,omitempty"`
+	// health prober가 마지막으로 클러스터에 접근에 성공한 시간
+	LastContactTime *metav1.Time `json:"lastContactTime,omitempty"`
+
+	// 클러스터 생성 단계별 상태
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// 클러스터가 Failed 상태가 된 원인
+	FailureReason ClusterManagerFailureReason `json:"failureReason,omitempty"`
+	// 클러스터가 Failed 상태가 된 원인에 대한 상세 메시지
+	FailureMessage string `json:"failureMessage,omitempty"`
+	// retry annotation에 의해 마지막으로 재시도한 시간
+	LastRetryTime *metav1.Time `json:"lastRetryTime,omitempty"`
 
 	// will be deprecated
 	PrometheusReady bool `json:"prometheusReady,omitempty"`
@@ -149,6 +293,24 @@ const (
 	ClusterManagerPhaseUpgrading = ClusterManagerPhase("Upgrading")
 	// 클러스터가 스케일링 중인 상태
 	ClusterManagerPhaseScaling = ClusterManagerPhase("Scaling")
+	// 단계별 제한 시간을 초과했거나 재시도할 수 없는 에러가 발생한 상태
+	// retry annotation을 추가하기 전까지는 reconcile을 수행하지 않는다.
+	ClusterManagerPhaseFailed = ClusterManagerPhase("Failed")
+)
+
+type ClusterManagerFailureReason string
+
+const (
+	FailureReasonInfrastructureTimeout = ClusterManagerFailureReason("InfrastructureTimeout")
+	FailureReasonControlPlaneTimeout   = ClusterManagerFailureReason("ControlPlaneTimeout")
+	FailureReasonArgoTimeout           = ClusterManagerFailureReason("ArgoRegistrationTimeout")
+	FailureReasonGatewayTimeout        = ClusterManagerFailureReason("GatewayTimeout")
+	FailureReasonAuthClientTimeout     = ClusterManagerFailureReason("AuthClientTimeout")
+	FailureReasonTraefikTimeout        = ClusterManagerFailureReason("TraefikTimeout")
+	FailureReasonUpgradeTimeout        = ClusterManagerFailureReason("UpgradeTimeout")
+	FailureReasonScalingTimeout        = ClusterManagerFailureReason("ScalingTimeout")
+	// 재시도해도 해결되지 않는 에러(잘못된 spec, api server의 invalid 응답 등)
+	FailureReasonInvalidConfiguration = ClusterManagerFailureReason("InvalidConfiguration")
 )
 
 // deprecated phases
@@ -200,10 +362,18 @@ const (
 	ClusterTypeCreated    = "created"
 	ClusterTypeRegistered = "registered"
 
-	AnnotationKeyClmApiserver      = "clustermanager.cluster.tmax.io/apiserver"
-	AnnotationKeyClmGateway        = "clustermanager.cluster.tmax.io/gateway"
-	AnnotationKeyClmSuffix         = "clustermanager.cluster.tmax.io/suffix"
-	AnnotationKeyClmDomain         = "clustermanager.cluster.tmax.io/domain"
+	AnnotationKeyClmApiserver = "clustermanager.cluster.tmax.io/apiserver"
+	AnnotationKeyClmGateway   = "clustermanager.cluster.tmax.io/gateway"
+	AnnotationKeyClmSuffix    = "clustermanager.cluster.tmax.io/suffix"
+	AnnotationKeyClmDomain    = "clustermanager.cluster.tmax.io/domain"
+	// Failed 상태의 클러스터를 재시도하기 위한 annotation, 처리 후 삭제된다.
+	AnnotationKeyClmRetry = "clustermanager.cluster.tmax.io/retry"
+	// 만료 경고를 보낸 만료 시각, 만료 시각이 연장되면 다시 경고한다.
+	AnnotationKeyClmExpiryWarning = "clustermanager.cluster.tmax.io/expiry-warning"
+	// detach된 cluster의 kubeconfig secret에 표시하며, secret controller가 처리하지 않는다.
+	AnnotationKeyClmDetached = "clustermanager.cluster.tmax.io/detached"
+	// 값이 "true"이면 spec.deletionProtection과 동일하게 cluster manager의 삭제를 막는다.
+	AnnotationKeyClmDeletionProtection = "clustermanager.cluster.tmax.io/deletion-protection"
 
 	LabelKeyClmName               = "clustermanager.cluster.tmax.io/clm-name"
 	LabelKeyClmNamespace          = "clustermanager.cluster.tmax.io/clm-namespace"
@@ -218,10 +388,20 @@ const (
 )
 
 const (
-	ProviderAWS     = "AWS"
-	ProviderVSphere = "vSphere"
+	ProviderAWS       = "AWS"
+	ProviderVSphere   = "vSphere"
+	ProviderOpenStack = "OpenStack"
+	ProviderDocker    = "Docker"
 )
 
+const (
+	// workerNum으로 생성되는 기본 worker pool의 이름
+	DefaultWorkerPoolName = "md-0"
+)
+
+// upgrade, controlplane용 리소스 이름과 겹치지 않도록 사용할 수 없는 worker pool 이름
+var ReservedWorkerPoolNames = []string{DefaultWorkerPoolName, "worker", "controlplane", "control-plane"}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:path=clustermanagers,scope=Namespaced,shortName=clm
@@ -233,15 +413,21 @@ const (
 // +kubebuilder:printcolumn:name="WorkerNum",type="string",JSONPath=".spec.workerNum",description="replica number of worker"
 // +kubebuilder:printcolumn:name="WorkerRun",type="string",JSONPath=".status.workerRun",description="running of worker"
 // +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="cluster status phase"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.failureReason",description="cluster failure reason",priority=1
+// +kubebuilder:printcolumn:name="ExpiresAt",type="string",JSONPath=".spec.expiresAt",description="cluster expiry time",priority=1
+// +kubebuilder:printcolumn:name="Paused",type="boolean",JSONPath=".spec.paused",description="reconciliation is paused",priority=1
+// +kubebuilder:printcolumn:name="Protected",type="boolean",JSONPath=".spec.deletionProtection",description="deletion protection",priority=1
 // ClusterManager is the Schema for the clustermanagers API
 type ClusterManager struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec        ClusterManagerSpec   `json:"spec"`
-	Status      ClusterManagerStatus `json:"status,omitempty"`
-	AwsSpec     ProviderAwsSpec      `json:"awsSpec,omitempty"`
-	VsphereSpec ProviderVsphereSpec  `json:"vsphereSpec,omitempty"`
+	Spec          ClusterManagerSpec    `json:"spec"`
+	Status        ClusterManagerStatus  `json:"status,omitempty"`
+	AwsSpec       ProviderAwsSpec       `json:"awsSpec,omitempty"`
+	VsphereSpec   ProviderVsphereSpec   `json:"vsphereSpec,omitempty"`
+	OpenstackSpec ProviderOpenstackSpec `json:"openstackSpec,omitempty"`
+	DockerSpec    ProviderDockerSpec    `json:"dockerSpec,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -287,6 +473,115 @@ func (c ClusterManagerStatus) GetTypedPhase() ClusterManagerPhase {
 	return c.Phase
 }
 
+func (c *ClusterManagerStatus) SetFailure(reason ClusterManagerFailureReason, message string) {
+	c.FailureReason = reason
+	c.FailureMessage = message
+}
+
+func (c *ClusterManagerStatus) ClearFailure() {
+	c.FailureReason = ""
+	c.FailureMessage = ""
+}
+
+func (c ClusterManagerStatus) IsFailed() bool {
+	return c.FailureReason != ""
+}
+
+// GetWorkerPools는 기본 worker pool(md-0)을 포함한 전체 worker pool 목록을 반환한다.
+func (c *ClusterManager) GetWorkerPools() []WorkerPool {
+	pools := []WorkerPool{
+		{
+			Name:     DefaultWorkerPoolName,
+			Replicas: c.Spec.WorkerNum,
+		},
+	}
+	return append(pools, c.Spec.WorkerPools...)
+}
+
+func (c *ClusterManager) GetWorkerPool(name string) *WorkerPool {
+	for i := range c.Spec.WorkerPools {
+		if c.Spec.WorkerPools[i].Name == name {
+			return &c.Spec.WorkerPools[i]
+		}
+	}
+	return nil
+}
+
+// TemplateHash는 replicas를 제외한 worker pool spec의 hash를 반환한다.
+// 삭제 후 같은 이름으로 다시 추가되어 template이 변경된 pool을 확인하기 위해 사용한다.
+func (p WorkerPool) TemplateHash() string {
+	p.Replicas = 0
+	data, _ := json.Marshal(p)
+	h := fnv.New32a()
+	_, _ = h.Write(data)
+	return strconv.FormatUint(uint64(h.Sum32()), 16)
+}
+
+func (c *ClusterManager) GetMachineDeploymentName(poolName string) string {
+	return c.Name + "-" + poolName
+}
+
+// GetTotalWorkerNum은 모든 worker pool의 worker node 수의 합을 반환한다.
+func (c *ClusterManager) GetTotalWorkerNum() int {
+	total := 0
+	for _, pool := range c.GetWorkerPools() {
+		total += pool.Replicas
+	}
+	return total
+}
+
+// IsWorkerScaling은 worker pool 중 replica가 변경된 pool이 있는지 확인한다.
+// 새로 추가된 pool은 scaling이 아닌 pool 생성으로 처리한다.
+func (c *ClusterManager) IsWorkerScaling() bool {
+	if c.Status.WorkerNum != 0 && c.Spec.WorkerNum != c.Status.WorkerNum {
+		return true
+	}
+	for _, pool := range c.Spec.WorkerPools {
+		if status := c.Status.GetWorkerPoolStatus(pool.Name); status != nil && status.Replicas != pool.Replicas {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ClusterManagerStatus) GetWorkerPoolStatus(name string) *WorkerPoolStatus {
+	for i := range c.WorkerPools {
+		if c.WorkerPools[i].Name == name {
+			return &c.WorkerPools[i]
+		}
+	}
+	return nil
+}
+
+func (c *ClusterManagerStatus) SetWorkerPoolStatus(status WorkerPoolStatus) {
+	if s := c.GetWorkerPoolStatus(status.Name); s != nil {
+		*s = status
+		return
+	}
+	c.WorkerPools = append(c.WorkerPools, status)
+}
+
+func (c *ClusterManagerStatus) RemoveWorkerPoolStatus(name string) {
+	for i := range c.WorkerPools {
+		if c.WorkerPools[i].Name == name {
+			c.WorkerPools = append(c.WorkerPools[:i], c.WorkerPools[i+1:]...)
+			return
+		}
+	}
+}
+
+func (c *ClusterManager) GetDeletionPolicy() ClusterDeletionPolicy {
+	if c.Spec.DeletionPolicy == "" {
+		return ClusterDeletionPolicyDelete
+	}
+	return c.Spec.DeletionPolicy
+}
+
+// IsDeletionProtected는 spec 또는 annotation으로 삭제 보호가 설정되었는지 반환한다.
+func (c *ClusterManager) IsDeletionProtected() bool {
+	return c.Spec.DeletionProtection || c.Annotations[AnnotationKeyClmDeletionProtection] == "true"
+}
+
 func (c ClusterManager) GetK8SVersion() string {
 	return c.Spec.Version
 }
@@ -16,8 +16,12 @@ package v1alpha1
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/version"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -46,7 +50,7 @@ func (r *ClusterManager) SetupWebhookWithManager(mgr ctrl.Manager) error {
 // }
 
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
-// +kubebuilder:webhook:verbs=update,path=/validate-cluster-tmax-io-v1alpha1-clustermanager,mutating=false,failurePolicy=fail,groups=cluster.tmax.io,resources=clustermanagers,versions=v1alpha1,name=validation.webhook.clustermanager,admissionReviewVersions=v1beta1;v1,sideEffects=NoneOnDryRun
+// +kubebuilder:webhook:verbs=update;delete,path=/validate-cluster-tmax-io-v1alpha1-clustermanager,mutating=false,failurePolicy=fail,groups=cluster.tmax.io,resources=clustermanagers,versions=v1alpha1,name=validation.webhook.clustermanager,admissionReviewVersions=v1beta1;v1,sideEffects=NoneOnDryRun
 
 var _ webhook.Validator = &ClusterManager{}
 
@@ -55,6 +59,11 @@ func (r *ClusterManager) ValidateCreate() error {
 	ClusterManagerWebhookLogger.Info("validate create", "name", r.Name)
 
 	// TODO(user): fill in your validation logic upon object creation.
+	if r.Spec.MaintenanceWindow != nil {
+		if err := r.Spec.MaintenanceWindow.Validate(); err != nil {
+			return fmt.Errorf("invalid spec.maintenanceWindow: %v", err)
+		}
+	}
 	return nil
 }
 
@@ -68,6 +77,12 @@ func (r *ClusterManager) ValidateUpdate(old runtime.Object) error {
 		return errors.New("cannot modify clusterManager.Annotations.owner")
 	}
 
+	if r.Spec.MaintenanceWindow != nil {
+		if err := r.Spec.MaintenanceWindow.Validate(); err != nil {
+			return fmt.Errorf("invalid spec.maintenanceWindow: %v", err)
+		}
+	}
+
 	// if r.Status.Ready == false {
 	// 	if !reflect.DeepEqual(r.Status.Members, oldClusterClaim.Status.Members) {
 	// 		return errors.New("Cannot modify members when cluster status is not ready")
@@ -88,15 +103,21 @@ func (r *ClusterManager) ValidateUpdate(old runtime.Object) error {
 			}
 		}
 
+		if err := validateWorkerPoolUpdate(r.Spec.WorkerPools, oldClusterManager.Spec.WorkerPools); err != nil {
+			return err
+		}
+
 		// scaling을 못하는 경우
 		masterNumChanged := r.Spec.MasterNum != oldClusterManager.Spec.MasterNum
-		workerNumChanged := r.Spec.WorkerNum != oldClusterManager.Spec.WorkerNum
+		workerNumChanged := r.Spec.WorkerNum != oldClusterManager.Spec.WorkerNum ||
+			!reflect.DeepEqual(r.Spec.WorkerPools, oldClusterManager.Spec.WorkerPools)
 		managerNotReady := oldClusterManager.Status.GetTypedPhase() != ClusterManagerPhaseReady
 		// testFlag := false
 		// scaling 복구를 위한 경우는 제외
 		masterNumRestored := r.Spec.MasterNum == oldClusterManager.Status.MasterNum
 		workerNumRestored := r.Spec.WorkerNum == oldClusterManager.Status.WorkerNum
-		mangerScaling := oldClusterManager.Status.GetTypedPhase() == ClusterManagerPhaseScaling
+		mangerScaling := oldClusterManager.Status.GetTypedPhase() == ClusterManagerPhaseScaling ||
+			oldClusterManager.Status.FailureReason == FailureReasonScalingTimeout
 		if ((masterNumChanged || workerNumChanged) && managerNotReady) &&
 			!((masterNumRestored || workerNumRestored) && mangerScaling) {
 			return errors.New("Cannot update MasterNum or WorkerNum at Processing, SyncNeeded, Upgrading or Deleting phases")
@@ -107,12 +128,24 @@ func (r *ClusterManager) ValidateUpdate(old runtime.Object) error {
 		managerNotReady = oldClusterManager.Status.GetTypedPhase() != ClusterManagerPhaseReady
 		// version upgrade 복구를 위한 경우는 제외
 		versionRestored := r.GetK8SVersion() == oldClusterManager.Status.Version
-		managerUpgrade := oldClusterManager.Status.GetTypedPhase() == ClusterManagerPhaseUpgrading
+		managerUpgrade := oldClusterManager.Status.GetTypedPhase() == ClusterManagerPhaseUpgrading ||
+			oldClusterManager.Status.FailureReason == FailureReasonUpgradeTimeout
 		if (versionChanged && managerNotReady) &&
 			!(versionRestored && managerUpgrade) {
 			return errors.New("Cannot update version at Progressing, Scaling or Deleting phases")
 		}
 
+		// 실행 중인 version에서 upgrade할 수 있는지 검사한다.
+		if versionChanged && !versionRestored {
+			current := oldClusterManager.Status.Version
+			if current == "" {
+				current = oldClusterManager.GetK8SVersion()
+			}
+			if err := ValidateVersionUpgrade(current, r.GetK8SVersion()); err != nil {
+				return err
+			}
+		}
+
 		if r.Spec.MasterNum%2 == 0 {
 			return errors.New("Cannot be an even number when using managed etcd")
 		}
@@ -121,10 +154,89 @@ func (r *ClusterManager) ValidateUpdate(old runtime.Object) error {
 	return nil
 }
 
+// SupportedVersions는 upgrade할 수 있는 kubernetes version 목록
+// v1.23과 같이 minor version까지 지정하면 해당 minor version의 모든 patch version을 허용하고, 비어있으면 모든 version을 허용한다.
+// manager를 시작할 때 설정한다.
+var SupportedVersions []string
+
+// ValidateVersionUpgrade는 current version에서 target version으로 upgrade할 수 있는지 검사한다.
+// kubernetes는 downgrade와 minor version을 건너뛰는 upgrade를 지원하지 않으므로 한 단계씩만 허용한다.
+func ValidateVersionUpgrade(current, target string) error {
+	currentVersion, err := version.ParseSemantic(current)
+	if err != nil {
+		return fmt.Errorf("cannot parse current version [%s]: %s", current, err.Error())
+	}
+	targetVersion, err := version.ParseSemantic(target)
+	if err != nil {
+		return fmt.Errorf("cannot parse version [%s]: %s", target, err.Error())
+	}
+
+	if !currentVersion.LessThan(targetVersion) {
+		return fmt.Errorf("version [%s] must be greater than current version [%s]", target, current)
+	}
+	if targetVersion.Major() != currentVersion.Major() || targetVersion.Minor() > currentVersion.Minor()+1 {
+		return fmt.Errorf("cannot skip minor version from [%s] to [%s]", current, target)
+	}
+	if !IsSupportedVersion(target) {
+		return fmt.Errorf("version [%s] is not supported. supported versions: [%s]", target, strings.Join(SupportedVersions, ", "))
+	}
+	return nil
+}
+
+// IsSupportedVersion은 version이 SupportedVersions에 포함되는지 확인한다.
+func IsSupportedVersion(v string) bool {
+	if len(SupportedVersions) == 0 {
+		return true
+	}
+	v = strings.TrimPrefix(v, "v")
+	for _, supported := range SupportedVersions {
+		supported = strings.TrimPrefix(supported, "v")
+		if v == supported || strings.HasPrefix(v, supported+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// validateWorkerPoolUpdate는 worker pool의 이름을 검사하고,
+// 이미 생성된 pool에 대해서는 replicas 외의 값을 변경하지 못하도록 한다.
+func validateWorkerPoolUpdate(pools, oldPools []WorkerPool) error {
+	for _, pool := range pools {
+		if IsReservedWorkerPoolName(pool.Name) {
+			return fmt.Errorf("worker pool name [%s] is reserved", pool.Name)
+		}
+		for _, oldPool := range oldPools {
+			if pool.Name != oldPool.Name {
+				continue
+			}
+			oldPool.Replicas = pool.Replicas
+			if !reflect.DeepEqual(pool, oldPool) {
+				return fmt.Errorf("cannot modify worker pool [%s] except replicas", pool.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func IsReservedWorkerPoolName(name string) bool {
+	for _, reserved := range ReservedWorkerPoolNames {
+		if name == reserved {
+			return true
+		}
+	}
+	return false
+}
+
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (r *ClusterManager) ValidateDelete() error {
 
 	ClusterManagerWebhookLogger.Info("validate delete", "name", r.Name)
 
+	// 이미 삭제 중인 경우는 막지 않는다.
+	if r.DeletionTimestamp.IsZero() && r.IsDeletionProtected() {
+		return fmt.Errorf("cluster manager %s is protected from deletion. "+
+			"Disable spec.deletionProtection and annotation %s first", r.Name, AnnotationKeyClmDeletionProtection)
+	}
+
 	return nil
 }
@@ -0,0 +1,74 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "testing"
+
+func TestValidateVersionUpgrade(t *testing.T) {
+	defer func(versions []string) { SupportedVersions = versions }(SupportedVersions)
+	SupportedVersions = []string{"v1.22", "v1.23.4"}
+
+	tests := []struct {
+		name    string
+		current string
+		target  string
+		wantErr bool
+	}{
+		{name: "patch upgrade", current: "v1.22.2", target: "v1.22.9", wantErr: false},
+		{name: "minor upgrade", current: "v1.22.9", target: "v1.23.4", wantErr: false},
+		{name: "without v prefix", current: "1.22.2", target: "1.23.4", wantErr: false},
+		{name: "skipped minor version", current: "v1.21.5", target: "v1.23.4", wantErr: true},
+		{name: "major upgrade", current: "v1.22.2", target: "v2.0.0", wantErr: true},
+		{name: "downgrade", current: "v1.23.4", target: "v1.22.9", wantErr: true},
+		{name: "same version", current: "v1.22.2", target: "v1.22.2", wantErr: true},
+		{name: "unsupported version", current: "v1.22.9", target: "v1.23.5", wantErr: true},
+		{name: "invalid target semver", current: "v1.22.2", target: "v1.23", wantErr: true},
+		{name: "invalid current semver", current: "latest", target: "v1.22.9", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateVersionUpgrade(tt.current, tt.target); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateVersionUpgrade(%s, %s) error = %v, wantErr %v", tt.current, tt.target, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsSupportedVersion(t *testing.T) {
+	defer func(versions []string) { SupportedVersions = versions }(SupportedVersions)
+
+	tests := []struct {
+		name      string
+		supported []string
+		version   string
+		want      bool
+	}{
+		{name: "all versions are supported if empty", supported: nil, version: "v1.30.1", want: true},
+		{name: "patch version of supported minor", supported: []string{"v1.23"}, version: "v1.23.7", want: true},
+		{name: "exact patch version", supported: []string{"v1.23.4"}, version: "v1.23.4", want: true},
+		{name: "other patch version", supported: []string{"v1.23.4"}, version: "v1.23.5", want: false},
+		{name: "without v prefix", supported: []string{"1.23"}, version: "v1.23.7", want: true},
+		{name: "minor with same prefix", supported: []string{"v1.2"}, version: "v1.23.7", want: false},
+		{name: "unsupported minor", supported: []string{"v1.22", "v1.23"}, version: "v1.24.1", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SupportedVersions = tt.supported
+			if got := IsSupportedVersion(tt.version); got != tt.want {
+				t.Errorf("IsSupportedVersion(%s) = %v, want %v", tt.version, got, tt.want)
+			}
+		})
+	}
+}
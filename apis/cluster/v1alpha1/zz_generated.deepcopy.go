@@ -21,6 +21,7 @@ package v1alpha1
 
 import (
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -29,10 +30,12 @@ func (in *ClusterManager) DeepCopyInto(out *ClusterManager) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	out.AwsSpec = in.AwsSpec
 	out.VsphereSpec = in.VsphereSpec
+	out.OpenstackSpec = in.OpenstackSpec
+	in.DockerSpec.DeepCopyInto(&out.DockerSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterManager.
@@ -88,6 +91,22 @@ func (in *ClusterManagerList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterManagerSpec) DeepCopyInto(out *ClusterManagerSpec) {
 	*out = *in
+	if in.WorkerPools != nil {
+		in, out := &in.WorkerPools, &out.WorkerPools
+		*out = make([]WorkerPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterManagerSpec.
@@ -105,9 +124,40 @@ func (in *ClusterManagerStatus) DeepCopyInto(out *ClusterManagerStatus) {
 	*out = *in
 	if in.NodeInfo != nil {
 		in, out := &in.NodeInfo, &out.NodeInfo
-		*out = make([]v1.NodeSystemInfo, len(*in))
+		*out = make([]NodeInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WorkerPools != nil {
+		in, out := &in.WorkerPools, &out.WorkerPools
+		*out = make([]WorkerPoolStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceType, len(*in))
 		copy(*out, *in)
 	}
+	if in.LastCollectedTime != nil {
+		in, out := &in.LastCollectedTime, &out.LastCollectedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastContactTime != nil {
+		in, out := &in.LastContactTime, &out.LastContactTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastRetryTime != nil {
+		in, out := &in.LastRetryTime, &out.LastRetryTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterManagerStatus.
@@ -214,6 +264,57 @@ func (in *ClusterRegistrationStatus) DeepCopy() *ClusterRegistrationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerMount) DeepCopyInto(out *DockerMount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerMount.
+func (in *DockerMount) DeepCopy() *DockerMount {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeInfo) DeepCopyInto(out *NodeInfo) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeInfo.
+func (in *NodeInfo) DeepCopy() *NodeInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderAwsSpec) DeepCopyInto(out *ProviderAwsSpec) {
 	*out = *in
@@ -229,6 +330,41 @@ func (in *ProviderAwsSpec) DeepCopy() *ProviderAwsSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderDockerSpec) DeepCopyInto(out *ProviderDockerSpec) {
+	*out = *in
+	if in.ExtraMounts != nil {
+		in, out := &in.ExtraMounts, &out.ExtraMounts
+		*out = make([]DockerMount, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderDockerSpec.
+func (in *ProviderDockerSpec) DeepCopy() *ProviderDockerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderDockerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderOpenstackSpec) DeepCopyInto(out *ProviderOpenstackSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderOpenstackSpec.
+func (in *ProviderOpenstackSpec) DeepCopy() *ProviderOpenstackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderOpenstackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderVsphereSpec) DeepCopyInto(out *ProviderVsphereSpec) {
 	*out = *in
@@ -258,3 +394,47 @@ func (in *ResourceType) DeepCopy() *ResourceType {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerPool) DeepCopyInto(out *WorkerPool) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]v1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerPool.
+func (in *WorkerPool) DeepCopy() *WorkerPool {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerPoolStatus) DeepCopyInto(out *WorkerPoolStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerPoolStatus.
+func (in *WorkerPoolStatus) DeepCopy() *WorkerPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
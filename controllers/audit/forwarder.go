@@ -0,0 +1,143 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	claimV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/claim/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// claim의 status.history 중 sink로 전송한 기록의 수
+const AnnotationKeyForwardedHistory = "claim.tmax.io/audit-forwarded-history"
+
+// HistoryForwarder는 cluster claim과 cluster update claim의 status.history에 추가된 기록을 Sink로 전송한다.
+// 전송한 기록의 수를 claim의 annotation에 기록하므로, 전송에 실패한 기록은 backoff 후 다시 전송하고
+// operator가 중단된 동안 추가된 기록은 재시작할 때 전송한다. annotation이 없는 claim은 모든 기록을 전송한다.
+// controller로 동작하므로 leader인 manager에서만 전송한다.
+type HistoryForwarder struct {
+	client.Client
+	Sink Sink
+	Log  logr.Logger
+}
+
+// +kubebuilder:rbac:groups=claim.tmax.io,resources=clusterclaims,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=claim.tmax.io,resources=clusterupdateclaims,verbs=get;list;watch;patch
+
+func (f *HistoryForwarder) SetupWithManager(mgr ctrl.Manager) error {
+	f.Client = mgr.GetClient()
+	err := ctrl.NewControllerManagedBy(mgr).
+		Named("audit-clusterclaim").
+		For(&claimV1alpha1.ClusterClaim{}).
+		Complete(&historyReconciler{
+			HistoryForwarder: f,
+			kind:             "ClusterClaim",
+			newObject:        func() client.Object { return &claimV1alpha1.ClusterClaim{} },
+		})
+	if err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("audit-clusterupdateclaim").
+		For(&claimV1alpha1.ClusterUpdateClaim{}).
+		Complete(&historyReconciler{
+			HistoryForwarder: f,
+			kind:             "ClusterUpdateClaim",
+			newObject:        func() client.Object { return &claimV1alpha1.ClusterUpdateClaim{} },
+		})
+}
+
+// historyReconciler는 claim 종류별로 전송하지 않은 기록을 전송한다.
+type historyReconciler struct {
+	*HistoryForwarder
+	kind      string
+	newObject func() client.Object
+}
+
+func (r *historyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("kind", r.kind, "namespace", req.Namespace, "name", req.Name)
+
+	obj := r.newObject()
+	if err := r.Get(ctx, req.NamespacedName, obj); errors.IsNotFound(err) {
+		return ctrl.Result{}, nil
+	} else if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	clusterName, history := claimHistory(obj)
+	forwarded := forwardedHistory(obj)
+	if forwarded >= len(history) {
+		return ctrl.Result{}, nil
+	}
+
+	// history는 webhook에 의해 뒤에만 추가되므로 전송한 기록 이후의 기록을 순서대로 전송한다.
+	sent := forwarded
+	var sendErr error
+	for _, h := range history[forwarded:] {
+		record := Record{
+			Kind:               r.kind,
+			Namespace:          obj.GetNamespace(),
+			Name:               obj.GetName(),
+			ClusterName:        clusterName,
+			ClaimHistoryRecord: h,
+		}
+		if sendErr = r.Sink.Send(ctx, record); sendErr != nil {
+			log.Error(sendErr, "Failed to send audit record. Retry after backoff", "phase", record.Phase)
+			break
+		}
+		sent++
+	}
+
+	if sent > forwarded {
+		before := obj.DeepCopyObject().(client.Object)
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[AnnotationKeyForwardedHistory] = strconv.Itoa(sent)
+		obj.SetAnnotations(annotations)
+		if err := r.Patch(ctx, obj, client.MergeFrom(before)); err != nil {
+			log.Error(err, "Failed to record forwarded audit records")
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, sendErr
+}
+
+// claimHistory는 claim의 대상 cluster와 status.history를 반환한다.
+func claimHistory(obj client.Object) (string, []claimV1alpha1.ClaimHistoryRecord) {
+	switch claim := obj.(type) {
+	case *claimV1alpha1.ClusterClaim:
+		return claim.Spec.ClusterName, claim.Status.History
+	case *claimV1alpha1.ClusterUpdateClaim:
+		return claim.Spec.ClusterName, claim.Status.History
+	}
+	return "", nil
+}
+
+// forwardedHistory는 annotation에 기록된 전송한 기록의 수를 반환한다. 값이 올바르지 않으면 0을 반환한다.
+func forwardedHistory(obj client.Object) int {
+	forwarded, err := strconv.Atoi(obj.GetAnnotations()[AnnotationKeyForwardedHistory])
+	if err != nil || forwarded < 0 {
+		return 0
+	}
+	return forwarded
+}
@@ -0,0 +1,87 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	claimV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/claim/v1alpha1"
+)
+
+const defaultSinkTimeout = 10 * time.Second
+
+// Record는 audit sink로 전송하는 claim의 phase 변경 기록
+type Record struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// claim의 대상 cluster
+	ClusterName string `json:"clusterName"`
+	claimV1alpha1.ClaimHistoryRecord
+}
+
+// Sink는 audit record를 외부로 전송한다.
+type Sink interface {
+	Send(ctx context.Context, record Record) error
+}
+
+// WebhookSink는 audit record를 JSON으로 URL에 POST한다.
+type WebhookSink struct {
+	URL    string
+	Client *http.Client
+}
+
+var _ Sink = &WebhookSink{}
+
+// NewWebhookSink는 기본 timeout을 가진 WebhookSink를 반환한다.
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		URL:    url,
+		Client: &http.Client{Timeout: defaultSinkTimeout},
+	}
+}
+
+func (s *WebhookSink) Send(ctx context.Context, record Record) error {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("audit webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	claimV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/claim/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWebhookSinkSend(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("unexpected content type %s", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	record := Record{
+		Kind:        "ClusterUpdateClaim",
+		Namespace:   "default",
+		Name:        "scale-prod-01",
+		ClusterName: "prod-01",
+		ClaimHistoryRecord: claimV1alpha1.ClaimHistoryRecord{
+			Time:          metav1.NewTime(time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)),
+			User:          "admin@tmax.co.kr",
+			Groups:        []string{"hypercloud5"},
+			PreviousPhase: "Awaiting",
+			Phase:         "Approved",
+			Message:       "worker 3 -> 7",
+		},
+	}
+	if err := NewWebhookSink(server.URL).Send(context.Background(), record); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"kind":          "ClusterUpdateClaim",
+		"namespace":     "default",
+		"name":          "scale-prod-01",
+		"clusterName":   "prod-01",
+		"time":          "2022-01-02T03:04:05Z",
+		"user":          "admin@tmax.co.kr",
+		"previousPhase": "Awaiting",
+		"phase":         "Approved",
+		"message":       "worker 3 -> 7",
+	}
+	for key, value := range expected {
+		if received[key] != value {
+			t.Errorf("%s: expected %v, got %v", key, value, received[key])
+		}
+	}
+	if groups, ok := received["groups"].([]interface{}); !ok || len(groups) != 1 || groups[0] != "hypercloud5" {
+		t.Errorf("groups: expected [hypercloud5], got %v", received["groups"])
+	}
+	if _, ok := received["reason"]; ok {
+		t.Errorf("reason: expected to be omitted, got %v", received["reason"])
+	}
+}
+
+func TestWebhookSinkSendError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	if err := NewWebhookSink(server.URL).Send(context.Background(), Record{}); err == nil {
+		t.Fatal("expected error for 500 response")
+	}
+}
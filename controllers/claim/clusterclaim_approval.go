@@ -0,0 +1,60 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sort"
+
+	claimV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/claim/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=claim.tmax.io,resources=clusterclaimapprovalpolicies,verbs=get;list;watch
+
+// findClusterClaimApprovalPolicy는 cluster claim에 적용할 승인 policy를 찾는다.
+// 여러 policy를 만족하면 이름순으로 첫번째 policy를 적용한다.
+// label은 claim을 생성한 사용자가 변경할 수 있으므로 namespace로만 policy를 선택한다.
+func (r *ClusterClaimReconciler) findClusterClaimApprovalPolicy(ctx context.Context, cc *claimV1alpha1.ClusterClaim) (*claimV1alpha1.ClusterClaimApprovalPolicy, error) {
+	policyList := &claimV1alpha1.ClusterClaimApprovalPolicyList{}
+	if err := r.List(ctx, policyList); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(policyList.Items, func(i, j int) bool {
+		return policyList.Items[i].Name < policyList.Items[j].Name
+	})
+
+	for i := range policyList.Items {
+		policy := &policyList.Items[i]
+		if len(policy.Spec.Namespaces) > 0 && !containsString(policy.Spec.Namespaces, cc.Namespace) {
+			continue
+		}
+		return policy, nil
+	}
+	return nil, nil
+}
+
+// setApprovalStages는 policy의 stage를 cluster claim의 status에 기록한다.
+// 이후에 policy가 변경되어도 claim에는 기록된 stage를 적용한다.
+func setApprovalStages(cc *claimV1alpha1.ClusterClaim, policy *claimV1alpha1.ClusterClaimApprovalPolicy) {
+	cc.Status.ApprovalPolicy = policy.Name
+	cc.Status.ApprovalStages = nil
+	for _, stage := range policy.Spec.Stages {
+		cc.Status.ApprovalStages = append(cc.Status.ApprovalStages, claimV1alpha1.ClaimApprovalStage{
+			Name:   stage.Name,
+			Groups: append([]string(nil), stage.Groups...),
+		})
+	}
+}
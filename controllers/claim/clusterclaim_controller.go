@@ -16,15 +16,18 @@ package controllers
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	claimV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/claim/v1alpha1"
 	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
 
+	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -46,12 +49,19 @@ const (
 // ClusterClaimReconciler reconciles a ClusterClaim object
 type ClusterClaimReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// cache를 거치지 않고 조회할 때 사용한다.
+	APIReader client.Reader
+	// cost estimate에 사용하는 가격표 ConfigMap. 이름이 비어있으면 비용은 계산하지 않는다.
+	CostEstimateConfigMap types.NamespacedName
 }
 
 // +kubebuilder:rbac:groups=claim.tmax.io,resources=clusterclaims,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=claim.tmax.io,resources=clusterclaims/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=claim.tmax.io,resources=clusterclaimtemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=claim.tmax.io,resources=clusterclaimpolicies,verbs=get;list;watch
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=get;list;watch;create;update;patch;delete
@@ -74,32 +84,102 @@ func (r *ClusterClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, err
 	}
 
+	// dry run인 claim은 생성할 resource만 렌더링하고 승인을 처리하지 않는다.
+	NotApproved := clusterClaim.Status.Phase == "" || clusterClaim.Status.Phase == claimV1alpha1.ClusterClaimPhaseAwaiting
+	if clusterClaim.Spec.DryRun && NotApproved {
+		return r.reconcileDryRun(context.TODO(), clusterClaim)
+	}
+
 	if !AutoAdmit {
 		Awaiting := clusterClaim.Status.Phase == claimV1alpha1.ClusterClaimPhaseAwaiting
 		if clusterClaim.Status.Phase == "" {
-			clusterClaim.Status.SetTypedPhase(claimV1alpha1.ClusterClaimPhaseAwaiting)
-			clusterClaim.Status.SetReason("Waiting for admin approval")
-			err := r.Status().Update(context.TODO(), clusterClaim)
+			// 여러 단계의 승인이 필요한 claim은 자동으로 승인하지 않는다.
+			approvalPolicy, err := r.findClusterClaimApprovalPolicy(context.TODO(), clusterClaim)
+			if err != nil {
+				log.Error(err, "Failed to find ClusterClaimApprovalPolicy")
+				return ctrl.Result{}, err
+			}
+
+			// policy를 만족하면 관리자의 승인 없이 approved로 변경한다.
+			var policy *claimV1alpha1.ClusterClaimPolicy
+			var failures []string
+			if approvalPolicy == nil {
+				policy, failures, err = r.evaluateClusterClaimPolicies(context.TODO(), clusterClaim)
+				if err != nil {
+					log.Error(err, "Failed to evaluate ClusterClaimPolicies")
+					return ctrl.Result{}, err
+				}
+			}
+			if approvalPolicy != nil {
+				log.Info("ClusterClaim requires approval stages", "policy", approvalPolicy.Name)
+				setApprovalStages(clusterClaim, approvalPolicy)
+				clusterClaim.Status.SetTypedPhase(claimV1alpha1.ClusterClaimPhaseAwaiting)
+				clusterClaim.Status.SetReason("Waiting for approval of stage [" + clusterClaim.GetCurrentApprovalStage().Name + "]")
+			} else if policy != nil {
+				log.Info("ClusterClaim is approved by ClusterClaimPolicy", "policy", policy.Name)
+				clusterClaim.Status.SetTypedPhase(claimV1alpha1.ClusterClaimPhaseApproved)
+				clusterClaim.Status.SetReason("Approved by ClusterClaimPolicy [" + policy.Name + "]")
+			} else {
+				clusterClaim.Status.SetTypedPhase(claimV1alpha1.ClusterClaimPhaseAwaiting)
+				reason := "Waiting for admin approval"
+				if len(failures) > 0 {
+					reason += ": " + strings.Join(failures, ", ")
+				}
+				clusterClaim.Status.SetReason(reason)
+			}
+			clusterClaim.Status.Estimate = r.estimateClusterClaim(context.TODO(), clusterClaim)
+			err = r.Status().Update(context.TODO(), clusterClaim)
 			if err != nil {
 				log.Error(err, "Failed to update ClusterClaim status")
 				return ctrl.Result{}, err
 			}
+			if policy != nil {
+				r.Recorder.Event(clusterClaim, coreV1.EventTypeNormal, "Approved", clusterClaim.Status.Reason)
+			} else {
+				r.Recorder.Event(clusterClaim, coreV1.EventTypeNormal, "WaitingForApproval", clusterClaim.Status.Reason)
+			}
 			return ctrl.Result{}, nil
 		} else if Awaiting {
+			// 승인 전에 spec이 변경된 경우 estimate를 다시 계산한다.
+			if estimate := clusterClaim.Status.Estimate; estimate == nil || estimate.ObservedGeneration != clusterClaim.Generation {
+				clusterClaim.Status.Estimate = r.estimateClusterClaim(context.TODO(), clusterClaim)
+				if err := r.Status().Update(context.TODO(), clusterClaim); err != nil {
+					log.Error(err, "Failed to update ClusterClaim status")
+					return ctrl.Result{}, err
+				}
+			}
 			return ctrl.Result{}, nil
 		}
 	}
 
+	decided, err := r.setApproverAnnotation(clusterClaim)
+	if err != nil {
+		log.Error(err, "Failed to set approver annotation")
+		return ctrl.Result{}, err
+	}
+
 	// console로부터 approved로 변경시 clustermanager 생성
 	Approved := clusterClaim.Status.Phase == claimV1alpha1.ClusterClaimPhaseApproved
 	if Approved {
+		// webhook을 거치지 않고 approved로 변경된 경우에도 모든 stage가 승인되기 전에는 생성하지 않는다.
+		if !clusterClaim.IsApprovalCompleted() {
+			log.Info("Waiting for approval stages", "stage", clusterClaim.GetCurrentApprovalStage().Name)
+			return ctrl.Result{}, nil
+		}
 		if err := r.CreateClusterManager(context.TODO(), clusterClaim); err != nil {
 			log.Error(err, "Failed to Create ClusterManager")
+			r.Recorder.Eventf(clusterClaim, coreV1.EventTypeWarning, "ClusterManagerCreationFailed", "Failed to create ClusterManager: %v", err)
 			return ctrl.Result{RequeueAfter: requeueAfter10Second}, nil
 		}
 		return ctrl.Result{}, nil
 	}
 
+	// 거절은 console에서 status를 변경하므로 사유와 함께 기록만 한다.
+	// 승인/거절한 사용자를 처음 기록할 때만 event를 남겨 reconcile마다 반복되지 않도록 한다.
+	if decided && clusterClaim.Status.Phase == claimV1alpha1.ClusterClaimPhaseRejected {
+		r.Recorder.Eventf(clusterClaim, coreV1.EventTypeWarning, "Rejected", "ClusterClaim is rejected: %s", clusterClaim.Status.Reason)
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -130,14 +210,37 @@ func (r *ClusterClaimReconciler) RequeueClusterClaimsForClusterManager(o client.
 
 	cc.Status.SetTypedPhase(claimV1alpha1.ClusterClaimPhaseClusterDeleted)
 	cc.Status.SetReason("cluster is deleted")
+	if clm.GetDeletionPolicy() == clusterV1alpha1.ClusterDeletionPolicyDetach {
+		cc.Status.SetReason("cluster is detached from cluster manager")
+	}
 	err := r.Status().Update(context.TODO(), cc)
 	if err != nil {
 		log.Error(err, "Failed to update ClusterClaim status")
 		return nil //??
 	}
+	r.Recorder.Event(cc, coreV1.EventTypeNormal, "ClusterDeleted", cc.Status.Reason)
 	return nil
 }
 
+// setApproverAnnotation은 webhook이 status에 기록한 승인/거절한 사용자를 annotation에 기록한다.
+// status 변경 요청으로는 annotation을 변경할 수 없으므로 controller가 기록한다.
+// 승인/거절 후 처음 기록한 경우 true를 반환한다.
+func (r *ClusterClaimReconciler) setApproverAnnotation(cc *claimV1alpha1.ClusterClaim) (bool, error) {
+	if cc.Status.Approver == "" || cc.Annotations[claimV1alpha1.AnnotationKeyClaimApprover] == cc.Status.Approver {
+		return false, nil
+	}
+
+	before := cc.DeepCopy()
+	if cc.Annotations == nil {
+		cc.Annotations = map[string]string{}
+	}
+	cc.Annotations[claimV1alpha1.AnnotationKeyClaimApprover] = cc.Status.Approver
+	if err := r.Patch(context.TODO(), cc, client.MergeFrom(before)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (r *ClusterClaimReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	controller, err := ctrl.NewControllerManagedBy(mgr).
 		For(&claimV1alpha1.ClusterClaim{}).
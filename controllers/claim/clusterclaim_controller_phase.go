@@ -4,15 +4,19 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"strings"
+	"time"
+
+	"github.com/imdario/mergo"
 
 	claimV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/claim/v1alpha1"
 	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/provider"
 	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/util"
 	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func (r *ClusterClaimReconciler) CreateClusterManager(ctx context.Context, cc *claimV1alpha1.ClusterClaim) error {
@@ -28,6 +32,7 @@ func (r *ClusterClaimReconciler) CreateClusterManager(ctx context.Context, cc *c
 		if err := r.Create(context.TODO(), &clm); err != nil {
 			return err
 		}
+		r.Recorder.Eventf(cc, coreV1.EventTypeNormal, "ClusterManagerCreated", "ClusterManager %s is created", clm.Name)
 
 	} else if err != nil {
 		return err
@@ -37,12 +42,41 @@ func (r *ClusterClaimReconciler) CreateClusterManager(ctx context.Context, cc *c
 }
 
 func (r *ClusterClaimReconciler) ConstructClusterManagerByClaim(cc *claimV1alpha1.ClusterClaim) (clusterV1alpha1.ClusterManager, error) {
+	clm, p, err := convertClusterClaim(r.Client, cc)
+	if err != nil {
+		return clusterV1alpha1.ClusterManager{}, err
+	}
+	if err := p.LoadCredentials(context.TODO(), r.Client, &clm); err != nil {
+		return clusterV1alpha1.ClusterManager{}, err
+	}
+
+	return clm, nil
+}
+
+// convertClusterClaim은 cluster claim template과 provider 기본값을 적용한 cluster manager를 만든다.
+// 인증 정보는 설정하지 않는다.
+func convertClusterClaim(c client.Client, cc *claimV1alpha1.ClusterClaim) (clusterV1alpha1.ClusterManager, provider.Provider, error) {
+	cc, err := applyClusterClaimTemplate(c, cc)
+	if err != nil {
+		return clusterV1alpha1.ClusterManager{}, nil, err
+	}
+
 	clmSpec := clusterV1alpha1.ClusterManagerSpec{
 		Provider:  cc.Spec.Provider,
 		Version:   cc.Spec.Version,
 		MasterNum: cc.Spec.MasterNum,
 		WorkerNum: cc.Spec.WorkerNum,
 	}
+	for _, pool := range cc.Spec.WorkerPools {
+		clmSpec.WorkerPools = append(clmSpec.WorkerPools, NewWorkerPool(pool))
+	}
+	// ttl은 cluster manager를 생성하는 승인 시점부터 계산한다.
+	if cc.Spec.ExpiresAt != nil {
+		clmSpec.ExpiresAt = cc.Spec.ExpiresAt.DeepCopy()
+	} else if cc.Spec.TTL != nil {
+		expiresAt := metaV1.NewTime(time.Now().Add(cc.Spec.TTL.Duration))
+		clmSpec.ExpiresAt = &expiresAt
+	}
 
 	clm := clusterV1alpha1.ClusterManager{
 		ObjectMeta: metaV1.ObjectMeta{
@@ -60,156 +94,97 @@ func (r *ClusterClaimReconciler) ConstructClusterManagerByClaim(cc *claimV1alpha
 		},
 		Spec: clmSpec,
 	}
-
-	if util.IsAWSProvider(cc.Spec.Provider) {
-		awsSpec, err := NewAwsSpec(cc)
-		if err != nil {
-			return clusterV1alpha1.ClusterManager{}, err
-		}
-		clm.AwsSpec = awsSpec
-	} else if util.IsVsphereProvider(cc.Spec.Provider) {
-		vsphereSpec, err := NewVsphereSpec(cc)
-		if err != nil {
-			return clusterV1alpha1.ClusterManager{}, err
-		}
-
-		clm.VsphereSpec = vsphereSpec
-		if err := r.LoadVsphereCredentials(&clm); err != nil {
-			return clusterV1alpha1.ClusterManager{}, err
-		}
-	}
-
-	return clm, nil
-}
-
-// vsphere spec configuration
-func NewVsphereSpec(cc *claimV1alpha1.ClusterClaim) (clusterV1alpha1.ProviderVsphereSpec, error) {
-
-	podCidr := cc.Spec.ProviderVsphereSpec.PodCidr
-	if podCidr == "" {
-		podCidr = "10.0.0.0/16"
+	// 승인한 사용자를 cluster manager에도 남긴다.
+	if cc.Status.Approver != "" {
+		clm.Annotations[claimV1alpha1.AnnotationKeyClaimApprover] = cc.Status.Approver
 	}
 
-	thumbPrint, err := util.AddColonToThumbprint(cc.Spec.ProviderVsphereSpec.VcenterThumbprint)
+	p, err := provider.Get(cc.Spec.Provider)
 	if err != nil {
-		return clusterV1alpha1.ProviderVsphereSpec{}, err
+		return clusterV1alpha1.ClusterManager{}, nil, err
 	}
-
-	vmNetwork := cc.Spec.ProviderVsphereSpec.VcenterNetwork
-	if vmNetwork == "" {
-		vmNetwork = "VM Network"
-	}
-
-	vcenterFolder := cc.Spec.ProviderVsphereSpec.VcenterFolder
-	if vcenterFolder == "" {
-		vcenterFolder = "vm"
+	if err := p.ConvertClaim(cc, &clm); err != nil {
+		return clusterV1alpha1.ClusterManager{}, nil, err
 	}
 
-	cpu := cc.Spec.ProviderVsphereSpec.VcenterCpuNum
-	if cpu == 0 {
-		cpu = 2
-	}
+	return clm, p, nil
+}
 
-	mem := cc.Spec.ProviderVsphereSpec.VcenterMemSize
-	if mem == 0 {
-		mem = 4096
+// applyClusterClaimTemplate은 templateRef가 지정된 경우 cluster claim template의 값을 합친 claim을 반환한다.
+// claim에 입력한 값이 우선이고, 비어있는 값은 size, template 기본값 순으로 채운다.
+func applyClusterClaimTemplate(c client.Client, cc *claimV1alpha1.ClusterClaim) (*claimV1alpha1.ClusterClaim, error) {
+	if cc.Spec.TemplateRef == nil {
+		return cc, nil
 	}
 
-	diskSize := cc.Spec.ProviderVsphereSpec.VcenterDiskSize
-	if diskSize == 0 {
-		diskSize = 20
+	cct := &claimV1alpha1.ClusterClaimTemplate{}
+	key := types.NamespacedName{Name: cc.Spec.TemplateRef.Name}
+	if err := c.Get(context.TODO(), key, cct); err != nil {
+		return nil, fmt.Errorf("failed to get ClusterClaimTemplate [%s]: %w", cc.Spec.TemplateRef.Name, err)
 	}
-
-	vmPassword := cc.Spec.ProviderVsphereSpec.VMPassword
-	if vmPassword == "" {
-		vmPassword = "dG1heEAyMw=="
+	if cc.Spec.Provider != "" && cc.Spec.Provider != cct.Spec.Provider {
+		return nil, fmt.Errorf("provider [%s] does not match provider [%s] of ClusterClaimTemplate [%s]",
+			cc.Spec.Provider, cct.Spec.Provider, cct.Name)
 	}
 
-	return clusterV1alpha1.ProviderVsphereSpec{
-		PodCidr:             podCidr,
-		VcenterCpuNum:       cpu,
-		VcenterMemSize:      mem,
-		VcenterDiskSize:     diskSize,
-		VcenterThumbprint:   thumbPrint,
-		VcenterNetwork:      vmNetwork,
-		VcenterFolder:       vcenterFolder,
-		VMPassword:          vmPassword,
-		VcenterIp:           cc.Spec.ProviderVsphereSpec.VcenterIp,
-		VcenterDataCenter:   cc.Spec.ProviderVsphereSpec.VcenterDataCenter,
-		VcenterDataStore:    cc.Spec.ProviderVsphereSpec.VcenterDataStore,
-		VcenterResourcePool: cc.Spec.ProviderVsphereSpec.VcenterResourcePool,
-		VcenterKcpIp:        cc.Spec.ProviderVsphereSpec.VcenterKcpIp,
-		VcenterTemplate:     cc.Spec.ProviderVsphereSpec.VcenterTemplate,
-	}, nil
-}
+	merged := cc.DeepCopy()
+	merged.Spec.Provider = cct.Spec.Provider
 
-// aws spec configuration
-func NewAwsSpec(cc *claimV1alpha1.ClusterClaim) (clusterV1alpha1.ProviderAwsSpec, error) {
-	region := cc.Spec.ProviderAwsSpec.Region
-	if region == "" {
-		region = "ap-northeast-2"
-	}
-
-	masterType := cc.Spec.ProviderAwsSpec.MasterType
-	if masterType == "" {
-		masterType = "t3.medium"
-	}
-
-	workerType := cc.Spec.ProviderAwsSpec.WorkerType
-	if workerType == "" {
-		workerType = "t3.medium"
+	sizeName := cc.Spec.TemplateRef.Size
+	if sizeName != "" || cct.Spec.DefaultSize != "" {
+		size := cct.GetSize(sizeName)
+		if size == nil {
+			return nil, fmt.Errorf("size [%s] is not defined in ClusterClaimTemplate [%s]",
+				provider.DefaultString(sizeName, cct.Spec.DefaultSize), cct.Name)
+		}
+		sizeSpec := claimV1alpha1.ClusterClaimSpec{
+			MasterNum:             size.MasterNum,
+			WorkerNum:             size.WorkerNum,
+			ProviderAwsSpec:       size.ProviderAwsSpec,
+			ProviderVsphereSpec:   size.ProviderVsphereSpec,
+			ProviderOpenstackSpec: size.ProviderOpenstackSpec,
+			ProviderDockerSpec:    size.ProviderDockerSpec,
+		}
+		if err := mergo.Merge(&merged.Spec, sizeSpec); err != nil {
+			return nil, err
+		}
 	}
 
-	masterDiskSize := cc.Spec.ProviderAwsSpec.MasterDiskSize
-	if masterDiskSize == 0 {
-		masterDiskSize = 20
+	defaultSpec := claimV1alpha1.ClusterClaimSpec{
+		ProviderAwsSpec:       cct.Spec.ProviderAwsSpec,
+		ProviderVsphereSpec:   cct.Spec.ProviderVsphereSpec,
+		ProviderOpenstackSpec: cct.Spec.ProviderOpenstackSpec,
+		ProviderDockerSpec:    cct.Spec.ProviderDockerSpec,
 	}
-
-	workerDiskSize := cc.Spec.ProviderAwsSpec.WorkerDiskSize
-	if workerDiskSize == 0 {
-		workerDiskSize = 20
+	if err := mergo.Merge(&merged.Spec, defaultSpec); err != nil {
+		return nil, err
 	}
 
-	return clusterV1alpha1.ProviderAwsSpec{
-		Region:         region,
-		MasterType:     masterType,
-		MasterDiskSize: masterDiskSize,
-		WorkerType:     workerType,
-		WorkerDiskSize: workerDiskSize,
-		SshKey:         cc.Spec.ProviderAwsSpec.SshKey,
-	}, nil
-}
-
-func (r *ClusterClaimReconciler) LoadVsphereCredentials(clm *clusterV1alpha1.ClusterManager) error {
-
-	key := types.NamespacedName{
-		Name:      "capv-manager-bootstrap-credentials",
-		Namespace: "capv-system",
+	// claim 생성 시에는 template의 값을 알 수 없으므로 합친 뒤에 검증한다.
+	if merged.Spec.MasterNum < 1 || merged.Spec.WorkerNum < 1 {
+		return nil, fmt.Errorf("masterNum and workerNum must be specified in ClusterClaim or size of ClusterClaimTemplate [%s]", cct.Name)
 	}
-
-	credential := &coreV1.Secret{}
-
-	if err := r.Client.Get(context.TODO(), key, credential); err != nil {
-		return err
+	if merged.Spec.MasterNum%2 == 0 {
+		return nil, fmt.Errorf("masterNum cannot be an even number when using managed etcd")
 	}
-
-	credentials, ok := credential.Data["credentials.yaml"]
-	if !ok {
-		return fmt.Errorf("credentials info not found in vsphere credential secret")
+	if errList := provider.ValidateClaim(merged); len(errList) > 0 {
+		return nil, errList.ToAggregate()
 	}
 
-	lines := strings.Split(string(credentials), "\n")
-	username := strings.Split(lines[0], ":")[1]
-	password := strings.Split(lines[1], ":")[1]
-
-	username = strings.TrimSpace(username)
-	password = strings.TrimSpace(password)
+	return merged, nil
+}
 
-	if username == "" || password == "" {
-		return fmt.Errorf("username or password not found in vsphere credential secret")
+// worker pool configuration
+// pool에 지정하지 않은 VM 사양은 cluster manager의 provider spec 값을 사용한다.
+func NewWorkerPool(pool claimV1alpha1.WorkerPoolClaimSpec) clusterV1alpha1.WorkerPool {
+	return clusterV1alpha1.WorkerPool{
+		Name:         pool.Name,
+		Replicas:     pool.Replicas,
+		InstanceType: pool.InstanceType,
+		DiskSize:     pool.DiskSize,
+		CpuNum:       pool.CpuNum,
+		MemSize:      pool.MemSize,
+		Labels:       pool.Labels,
+		Taints:       pool.Taints,
 	}
-
-	clm.VsphereSpec.VcenterId = username
-	clm.VsphereSpec.VcenterPassword = password
-	return nil
 }
@@ -0,0 +1,91 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	claimV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/claim/v1alpha1"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/provider"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// cost estimate ConfigMap에서 가격표의 통화를 작성하는 key
+// provider별 가격표는 provider 이름(e.g. AWS)을 key로 provider가 정의한 형식으로 작성한다.
+const CostEstimateKeyCurrency = "currency"
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get
+
+// estimateClusterClaim은 template과 provider 기본값이 적용된 사양으로 cluster의 resource와 비용을 계산한다.
+// 비용은 provider가 CostProvider를 구현하고 cost estimate ConfigMap에 provider의 가격표가 있는 경우에만 계산한다.
+func (r *ClusterClaimReconciler) estimateClusterClaim(ctx context.Context, cc *claimV1alpha1.ClusterClaim) *claimV1alpha1.ClusterClaimEstimate {
+	clm, p, err := convertClusterClaim(r.Client, cc)
+	if err != nil {
+		return &claimV1alpha1.ClusterClaimEstimate{ObservedGeneration: cc.Generation, Message: err.Error()}
+	}
+
+	nodeNum := clm.Spec.MasterNum + clm.Spec.WorkerNum
+	for _, pool := range clm.Spec.WorkerPools {
+		nodeNum += pool.Replicas
+	}
+	resources := provider.ClusterResources(&clm)
+	estimate := &claimV1alpha1.ClusterClaimEstimate{
+		ObservedGeneration: cc.Generation,
+		NodeNum:            nodeNum,
+		CpuNum:             resources.CpuNum,
+		MemSize:            resources.MemSize,
+		DiskSize:           resources.DiskSize,
+	}
+
+	cp, ok := p.(provider.CostProvider)
+	if !ok || r.CostEstimateConfigMap.Name == "" {
+		return estimate
+	}
+
+	// 모든 ConfigMap을 cache하지 않도록 API server에서 직접 조회한다.
+	var reader client.Reader = r.APIReader
+	if reader == nil {
+		reader = r.Client
+	}
+	cm := &coreV1.ConfigMap{}
+	if err := reader.Get(ctx, r.CostEstimateConfigMap, cm); errors.IsNotFound(err) {
+		return estimate
+	} else if err != nil {
+		estimate.Message = fmt.Sprintf("failed to get price table: %v", err)
+		return estimate
+	}
+
+	priceTable, ok := cm.Data[p.Name()]
+	if !ok {
+		return estimate
+	}
+	cost, missing, err := cp.EstimateHourlyCost(&clm, priceTable)
+	if err != nil {
+		estimate.Message = fmt.Sprintf("invalid price table of %s: %v", p.Name(), err)
+		return estimate
+	}
+	estimate.Currency = cm.Data[CostEstimateKeyCurrency]
+	estimate.HourlyCost = fmt.Sprintf("%.2f", cost)
+	estimate.MonthlyCost = fmt.Sprintf("%.2f", cost*provider.HoursPerMonth)
+	if len(missing) > 0 {
+		estimate.Message = "no price for " + strings.Join(missing, ", ")
+	}
+	return estimate
+}
@@ -0,0 +1,195 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	claimV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/claim/v1alpha1"
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	clusterController "github.com/tmax-cloud/hypercloud-multi-operator/controllers/cluster"
+	tmaxv1 "github.com/tmax-cloud/template-operator/api/v1"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+)
+
+// dry run 결과에서 password 대신 표시하는 값
+const maskedValue = "******"
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;create;update
+
+// reconcileDryRun은 승인되었을 때 생성할 cluster manager와 template instance를 plan ConfigMap에 기록한다.
+// 처음 phase를 변경하지 않으므로 승인 stage와 policy는 dry run을 해제한 후에 적용된다.
+func (r *ClusterClaimReconciler) reconcileDryRun(ctx context.Context, cc *claimV1alpha1.ClusterClaim) (ctrl.Result, error) {
+	log := r.Log.WithValues("ClusterClaim", types.NamespacedName{Name: cc.Name, Namespace: cc.Namespace})
+
+	if cc.Status.Plan != nil && cc.Status.Plan.ObservedGeneration == cc.Generation {
+		return ctrl.Result{}, nil
+	}
+
+	plan, err := r.planClusterClaim(ctx, cc)
+	if err != nil {
+		log.Error(err, "Failed to write plan of ClusterClaim")
+		return ctrl.Result{}, err
+	}
+	cc.Status.Plan = plan
+	cc.Status.Estimate = r.estimateClusterClaim(ctx, cc)
+	if err := r.Status().Update(ctx, cc); err != nil {
+		log.Error(err, "Failed to update ClusterClaim status")
+		return ctrl.Result{}, err
+	}
+
+	if plan.Message != "" {
+		r.Recorder.Eventf(cc, coreV1.EventTypeWarning, "PlanFailed", "Failed to render plan: %s", plan.Message)
+	} else {
+		r.Recorder.Eventf(cc, coreV1.EventTypeNormal, "PlanRendered", "Plan is rendered to ConfigMap %s", plan.ConfigMap)
+	}
+	return ctrl.Result{}, nil
+}
+
+// planClusterClaim은 cluster manager와 template instance를 렌더링한다.
+// 렌더링할 수 없는 claim은 plan의 message에 이유를 기록하고, ConfigMap을 쓰지 못한 경우에만 error를 반환한다.
+// 인증 정보는 cluster manager를 생성할 때 읽으므로 plan에는 포함되지 않는다.
+func (r *ClusterClaimReconciler) planClusterClaim(ctx context.Context, cc *claimV1alpha1.ClusterClaim) (*claimV1alpha1.ClaimPlan, error) {
+	plan := &claimV1alpha1.ClaimPlan{
+		ObservedGeneration: cc.Generation,
+		Time:               metaV1.Now(),
+	}
+
+	clm, p, err := convertClusterClaim(r.Client, cc)
+	if err != nil {
+		plan.Message = err.Error()
+		return plan, nil
+	}
+
+	objects := map[string]client.Object{
+		"clustermanager.yaml": &clm,
+	}
+	plan.Summary = append(plan.Summary, fmt.Sprintf("ClusterManager %s (master %d, worker %d)",
+		clm.Name, clm.Spec.MasterNum, clm.Spec.WorkerNum))
+
+	// cluster template instance 이름의 suffix는 생성할 때 정해진다.
+	ti, err := clusterController.BuildClusterTemplateInstance(p, &clm, clm.Name)
+	if err != nil {
+		plan.Message = err.Error()
+		return plan, nil
+	}
+	objects["templateinstance-cluster.yaml"] = ti
+	plan.Summary = append(plan.Summary, fmt.Sprintf("TemplateInstance %s (template %s)", ti.Name, ti.Spec.ClusterTemplate.Metadata.Name))
+
+	for _, pool := range clm.Spec.WorkerPools {
+		ti, err := clusterController.BuildWorkerPoolTemplateInstance(p, &clm, pool)
+		if err != nil {
+			plan.Message = err.Error()
+			return plan, nil
+		}
+		objects["templateinstance-"+pool.Name+".yaml"] = ti
+		plan.Summary = append(plan.Summary, fmt.Sprintf("TemplateInstance %s (template %s, replicas %d)",
+			ti.Name, ti.Spec.ClusterTemplate.Metadata.Name, pool.Replicas))
+	}
+
+	data, err := renderPlanObjects(r.Scheme, objects)
+	if err != nil {
+		plan.Message = err.Error()
+		return plan, nil
+	}
+
+	plan.ConfigMap, err = writePlanConfigMap(ctx, r.Client, r.APIReader, r.Scheme, cc, data)
+	return plan, err
+}
+
+// renderPlanObjects는 object를 yaml로 변환한다. cluster manager와 template instance parameter의 password는 가린다.
+func renderPlanObjects(scheme *runtime.Scheme, objects map[string]client.Object) (map[string]string, error) {
+	data := map[string]string{}
+	for key, obj := range objects {
+		if gvk, err := apiutil.GVKForObject(obj, scheme); err == nil {
+			obj.GetObjectKind().SetGroupVersionKind(gvk)
+		}
+		switch o := obj.(type) {
+		case *clusterV1alpha1.ClusterManager:
+			maskPassword(&o.VsphereSpec.VcenterPassword)
+			maskPassword(&o.VsphereSpec.VMPassword)
+		case *tmaxv1.TemplateInstance:
+			if o.Spec.ClusterTemplate != nil {
+				maskPasswordParams(o.Spec.ClusterTemplate.Parameters)
+			}
+		}
+		out, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		data[key] = string(out)
+	}
+	return data, nil
+}
+
+func maskPassword(password *string) {
+	if *password != "" {
+		*password = maskedValue
+	}
+}
+
+func maskPasswordParams(params []tmaxv1.ParamSpec) {
+	for i := range params {
+		if strings.Contains(params[i].Name, "PASSWORD") && params[i].Value.String() != "" {
+			params[i].Value = intstr.FromString(maskedValue)
+		}
+	}
+}
+
+// writePlanConfigMap은 claim이 소유하는 <claim 이름>-plan ConfigMap에 plan을 기록하고 ConfigMap 이름을 반환한다.
+// claim이 삭제되면 ConfigMap도 함께 삭제된다.
+func writePlanConfigMap(ctx context.Context, c client.Client, reader client.Reader, scheme *runtime.Scheme,
+	owner client.Object, data map[string]string) (string, error) {
+	// 모든 ConfigMap을 cache하지 않도록 API server에서 직접 조회한다.
+	if reader == nil {
+		reader = c
+	}
+
+	key := types.NamespacedName{Name: owner.GetName() + "-plan", Namespace: owner.GetNamespace()}
+	cm := &coreV1.ConfigMap{}
+	if err := reader.Get(ctx, key, cm); errors.IsNotFound(err) {
+		cm = &coreV1.ConfigMap{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+			},
+			Data: data,
+		}
+		if err := ctrl.SetControllerReference(owner, cm, scheme); err != nil {
+			return "", err
+		}
+		return key.Name, c.Create(ctx, cm)
+	} else if err != nil {
+		return "", err
+	}
+
+	if !metaV1.IsControlledBy(cm, owner) {
+		return "", fmt.Errorf("ConfigMap %s already exists and is not owned by the claim", key.Name)
+	}
+	cm.Data = data
+	return key.Name, c.Update(ctx, cm)
+}
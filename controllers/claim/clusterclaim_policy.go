@@ -0,0 +1,189 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	claimV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/claim/v1alpha1"
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/util"
+)
+
+// evaluateClusterClaimPolicies는 cluster claim을 자동으로 승인하는 policy를 찾는다.
+// 승인하는 policy가 없으면 policy별로 만족하지 못한 조건을 반환한다.
+func (r *ClusterClaimReconciler) evaluateClusterClaimPolicies(ctx context.Context, cc *claimV1alpha1.ClusterClaim) (*claimV1alpha1.ClusterClaimPolicy, []string, error) {
+	policyList := &claimV1alpha1.ClusterClaimPolicyList{}
+	if err := r.List(ctx, policyList); err != nil {
+		return nil, nil, err
+	}
+	if len(policyList.Items) == 0 {
+		return nil, nil, nil
+	}
+
+	// template, provider 기본값이 적용된 사양으로 검사한다.
+	clm, _, err := convertClusterClaim(r.Client, cc)
+	if err != nil {
+		return nil, []string{err.Error()}, nil
+	}
+
+	// 평가 순서가 항상 같도록 이름순으로 검사한다.
+	sort.Slice(policyList.Items, func(i, j int) bool {
+		return policyList.Items[i].Name < policyList.Items[j].Name
+	})
+
+	failures := []string{}
+	for i := range policyList.Items {
+		policy := &policyList.Items[i]
+		if reason := matchClusterClaimPolicy(policy, cc, &clm); reason != "" {
+			failures = append(failures, fmt.Sprintf("ClusterClaimPolicy [%s]: %s", policy.Name, reason))
+			continue
+		}
+		return policy, nil, nil
+	}
+	return nil, failures, nil
+}
+
+// matchClusterClaimPolicy는 cluster claim이 policy의 모든 조건을 만족하는지 검사한다.
+// 만족하지 못한 첫번째 조건을 반환하고, 모두 만족하면 빈 문자열을 반환한다.
+func matchClusterClaimPolicy(policy *claimV1alpha1.ClusterClaimPolicy, cc *claimV1alpha1.ClusterClaim, clm *clusterV1alpha1.ClusterManager) string {
+	spec := policy.Spec
+
+	if len(spec.Namespaces) > 0 && !containsString(spec.Namespaces, cc.Namespace) {
+		return fmt.Sprintf("namespace [%s] is not allowed", cc.Namespace)
+	}
+
+	if len(spec.Users) > 0 || len(spec.Groups) > 0 {
+		creator := cc.Annotations[claimV1alpha1.AnnotationKeyClaimCreator]
+		if !containsString(spec.Users, creator) && !containsAny(spec.Groups, claimV1alpha1.GetClaimCreatorGroups(cc)) {
+			return fmt.Sprintf("creator [%s] is not in allowed users or groups", creator)
+		}
+	}
+
+	if len(spec.Providers) > 0 && !containsString(spec.Providers, clm.Spec.Provider) {
+		return fmt.Sprintf("provider [%s] is not allowed", clm.Spec.Provider)
+	}
+
+	if spec.MaxMasterNum > 0 && clm.Spec.MasterNum > spec.MaxMasterNum {
+		return fmt.Sprintf("masterNum [%d] exceeds maxMasterNum [%d]", clm.Spec.MasterNum, spec.MaxMasterNum)
+	}
+
+	if spec.MaxWorkerNum != nil {
+		workerNum := clm.Spec.WorkerNum
+		for _, pool := range clm.Spec.WorkerPools {
+			workerNum += pool.Replicas
+		}
+		if workerNum > *spec.MaxWorkerNum {
+			return fmt.Sprintf("total worker number [%d] exceeds maxWorkerNum [%d]", workerNum, *spec.MaxWorkerNum)
+		}
+	}
+
+	if len(spec.Versions) > 0 && !isAllowedVersion(spec.Versions, clm.Spec.Version) {
+		return fmt.Sprintf("version [%s] is not allowed", clm.Spec.Version)
+	}
+
+	return matchInstanceLimits(spec.InstanceLimits, clm)
+}
+
+// matchInstanceLimits는 VM 사양이 policy의 제한을 넘는지 검사한다.
+// worker pool에 지정하지 않은 값은 provider spec의 값을 사용하므로 지정한 값만 검사한다.
+func matchInstanceLimits(limits claimV1alpha1.ClusterClaimPolicyInstanceLimits, clm *clusterV1alpha1.ClusterManager) string {
+	switch strings.ToUpper(clm.Spec.Provider) {
+	case util.ProviderAws:
+		if len(limits.AwsInstanceTypes) > 0 {
+			instanceTypes := []string{clm.AwsSpec.MasterType, clm.AwsSpec.WorkerType}
+			for _, pool := range clm.Spec.WorkerPools {
+				if pool.InstanceType != "" {
+					instanceTypes = append(instanceTypes, pool.InstanceType)
+				}
+			}
+			for _, t := range instanceTypes {
+				if !containsString(limits.AwsInstanceTypes, t) {
+					return fmt.Sprintf("instance type [%s] is not allowed", t)
+				}
+			}
+		}
+		disks := []int{clm.AwsSpec.MasterDiskSize, clm.AwsSpec.WorkerDiskSize}
+		if reason := exceedsLimit("disk size", disks, clm.Spec.WorkerPools, func(p clusterV1alpha1.WorkerPool) int { return p.DiskSize }, limits.MaxDiskSize); reason != "" {
+			return reason
+		}
+	case util.ProviderVsphere:
+		spec := clm.VsphereSpec
+		if reason := exceedsLimit("cpu number", []int{spec.VcenterCpuNum}, clm.Spec.WorkerPools, func(p clusterV1alpha1.WorkerPool) int { return p.CpuNum }, limits.MaxCpuNum); reason != "" {
+			return reason
+		}
+		if reason := exceedsLimit("memory size", []int{spec.VcenterMemSize}, clm.Spec.WorkerPools, func(p clusterV1alpha1.WorkerPool) int { return p.MemSize }, limits.MaxMemSize); reason != "" {
+			return reason
+		}
+		if reason := exceedsLimit("disk size", []int{spec.VcenterDiskSize}, clm.Spec.WorkerPools, func(p clusterV1alpha1.WorkerPool) int { return p.DiskSize }, limits.MaxDiskSize); reason != "" {
+			return reason
+		}
+	case util.ProviderOpenstack:
+		if len(limits.OpenstackFlavors) > 0 {
+			for _, flavor := range []string{clm.OpenstackSpec.MasterFlavor, clm.OpenstackSpec.WorkerFlavor} {
+				if !containsString(limits.OpenstackFlavors, flavor) {
+					return fmt.Sprintf("flavor [%s] is not allowed", flavor)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func exceedsLimit(name string, values []int, pools []clusterV1alpha1.WorkerPool, poolValue func(clusterV1alpha1.WorkerPool) int, limit int) string {
+	if limit <= 0 {
+		return ""
+	}
+	for _, pool := range pools {
+		values = append(values, poolValue(pool))
+	}
+	for _, v := range values {
+		if v > limit {
+			return fmt.Sprintf("%s [%d] exceeds limit [%d]", name, v, limit)
+		}
+	}
+	return ""
+}
+
+// isAllowedVersion은 version이 허용된 version과 같거나, 허용된 minor version의 patch version인지 확인한다.
+func isAllowedVersion(allowed []string, version string) bool {
+	for _, v := range allowed {
+		if version == v || strings.HasPrefix(version, v+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(list []string, items []string) bool {
+	for _, item := range items {
+		if containsString(list, item) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,450 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	claimV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/claim/v1alpha1"
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/provider"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/util"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// claimUsage는 cluster claim으로 생성되었거나 생성될 cluster의 사용량
+type claimUsage struct {
+	claim *claimV1alpha1.ClusterClaim
+	usage claimV1alpha1.ClusterQuotaUsage
+}
+
+// usageOfClusterManager는 cluster 하나의 사용량을 계산한다.
+func usageOfClusterManager(clm *clusterV1alpha1.ClusterManager) claimV1alpha1.ClusterQuotaUsage {
+	workerNum := clm.Spec.WorkerNum
+	for _, pool := range clm.Spec.WorkerPools {
+		workerNum += pool.Replicas
+	}
+	resources := provider.ClusterResources(clm)
+	return claimV1alpha1.ClusterQuotaUsage{
+		Clusters:  1,
+		MasterNum: clm.Spec.MasterNum,
+		WorkerNum: workerNum,
+		CpuNum:    resources.CpuNum,
+		MemSize:   resources.MemSize,
+	}
+}
+
+// listQuotaUsages는 namespace의 cluster claim과 반영되지 않은 cluster update claim의 사용량을 계산한다.
+// exclude는 검증 중인 claim으로, 변경 전의 사양이 사용량에 중복으로 계산되지 않도록 제외한다.
+func listQuotaUsages(ctx context.Context, c client.Client, namespace string, exclude client.Object) ([]claimUsage, error) {
+	usages, err := listClaimUsages(ctx, c, namespace, exclude)
+	if err != nil {
+		return nil, err
+	}
+	updateUsages, err := listUpdateClaimUsages(ctx, c, namespace, exclude)
+	if err != nil {
+		return nil, err
+	}
+	return append(usages, updateUsages...), nil
+}
+
+// listClaimUsages는 namespace의 cluster claim 중 cluster가 생성되었거나 승인을 기다리는 claim의 사용량을 계산한다.
+// dry run인 claim은 cluster를 생성하지 않으므로 제외한다.
+// cluster가 생성된 경우 node scale이 반영된 cluster manager의 사양으로 계산한다.
+func listClaimUsages(ctx context.Context, c client.Client, namespace string, exclude client.Object) ([]claimUsage, error) {
+	ccList := &claimV1alpha1.ClusterClaimList{}
+	if err := c.List(ctx, ccList, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	usages := []claimUsage{}
+	for i := range ccList.Items {
+		cc := &ccList.Items[i]
+		if cc.Spec.DryRun {
+			continue
+		}
+		if excluded, ok := exclude.(*claimV1alpha1.ClusterClaim); ok && excluded.Name == cc.Name {
+			continue
+		}
+		switch cc.Status.Phase {
+		case "", claimV1alpha1.ClusterClaimPhaseAwaiting, claimV1alpha1.ClusterClaimPhaseApproved:
+		default:
+			continue
+		}
+
+		clm := &clusterV1alpha1.ClusterManager{}
+		err := c.Get(ctx, cc.GetClusterManagerNamespacedName(), clm)
+		if err == nil && clm.Labels[clusterV1alpha1.LabelKeyClcName] == cc.Name {
+			usages = append(usages, claimUsage{claim: cc, usage: usageOfClusterManager(clm)})
+			continue
+		} else if err != nil && !errors.IsNotFound(err) {
+			return nil, err
+		}
+
+		// 사양을 계산할 수 없는 claim은 cluster를 생성할 수 없으므로 제외한다.
+		converted, _, err := convertClusterClaim(c, cc)
+		if err != nil {
+			continue
+		}
+		usages = append(usages, claimUsage{claim: cc, usage: usageOfClusterManager(&converted)})
+	}
+	return usages, nil
+}
+
+// listUpdateClaimUsages는 namespace의 node scale cluster update claim 중 승인을 기다리거나
+// 승인되었지만 cluster manager에 반영되지 않은 claim이 늘리는 사용량을 계산한다.
+// 사용량은 대상 cluster를 생성한 cluster claim의 사용량으로 계산하며, 줄어드는 항목은 사용량에서 빼지 않는다.
+func listUpdateClaimUsages(ctx context.Context, c client.Client, namespace string, exclude client.Object) ([]claimUsage, error) {
+	cucList := &claimV1alpha1.ClusterUpdateClaimList{}
+	if err := c.List(ctx, cucList, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	usages := []claimUsage{}
+	for i := range cucList.Items {
+		cuc := &cucList.Items[i]
+		if cuc.Spec.DryRun || cuc.GetUpdateType() != claimV1alpha1.ClusterUpdateTypeNodeScale || !isUpdateClaimPending(cuc) {
+			continue
+		}
+		if excluded, ok := exclude.(*claimV1alpha1.ClusterUpdateClaim); ok && excluded.Name == cuc.Name {
+			continue
+		}
+
+		clm := &clusterV1alpha1.ClusterManager{}
+		key := types.NamespacedName{Name: cuc.Spec.ClusterName, Namespace: cuc.Namespace}
+		if err := c.Get(ctx, key, clm); errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if clm.GetClusterType() != clusterV1alpha1.ClusterTypeCreated {
+			continue
+		}
+		cc, err := clusterClaimOf(ctx, c, clm)
+		if err != nil {
+			return nil, err
+		}
+
+		usage := updateClaimUsage(clm, updatedClusterManager(clm, cuc))
+		usage.MasterNum, usage.WorkerNum = nonNegative(usage.MasterNum), nonNegative(usage.WorkerNum)
+		usage.CpuNum, usage.MemSize = nonNegative(usage.CpuNum), nonNegative(usage.MemSize)
+		usages = append(usages, claimUsage{claim: cc, usage: usage})
+	}
+	return usages, nil
+}
+
+func nonNegative(n int) int {
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+// isUpdateClaimPending은 cluster update claim이 아직 cluster manager에 반영되지 않았는지 확인한다.
+// 승인된 claim은 controller가 cluster manager에 반영한 후 reason을 AdminApproved로 변경한다.
+func isUpdateClaimPending(cuc *claimV1alpha1.ClusterUpdateClaim) bool {
+	switch {
+	case cuc.IsPhaseEmpty(), cuc.IsPhaseAwaiting():
+		return true
+	case cuc.IsPhaseApproved():
+		return cuc.Status.Reason != claimV1alpha1.ClusterUpdateClaimReasonAdminApproved
+	}
+	return false
+}
+
+// clusterClaimOf는 cluster manager를 생성한 cluster claim을 반환한다. claim이 삭제된 경우 nil을 반환한다.
+func clusterClaimOf(ctx context.Context, c client.Client, clm *clusterV1alpha1.ClusterManager) (*claimV1alpha1.ClusterClaim, error) {
+	cc := &claimV1alpha1.ClusterClaim{}
+	key := types.NamespacedName{Name: clm.Labels[clusterV1alpha1.LabelKeyClcName], Namespace: clm.Namespace}
+	if err := c.Get(ctx, key, cc); errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return cc, nil
+}
+
+// updatedClusterManager는 cluster update claim의 node 수를 반영한 cluster manager를 반환한다.
+func updatedClusterManager(clm *clusterV1alpha1.ClusterManager, cuc *claimV1alpha1.ClusterUpdateClaim) *clusterV1alpha1.ClusterManager {
+	updated := clm.DeepCopy()
+	if cuc.Spec.UpdatedMasterNum != 0 {
+		updated.Spec.MasterNum = cuc.Spec.UpdatedMasterNum
+	}
+	if cuc.Spec.UpdatedWorkerNum != 0 {
+		updated.Spec.WorkerNum = cuc.Spec.UpdatedWorkerNum
+	}
+	for _, updatedPool := range cuc.Spec.UpdatedWorkerPools {
+		if pool := updated.GetWorkerPool(updatedPool.Name); pool != nil {
+			pool.Replicas = updatedPool.Replicas
+		} else {
+			updated.Spec.WorkerPools = append(updated.Spec.WorkerPools, NewWorkerPool(updatedPool))
+		}
+	}
+	return updated
+}
+
+// updateClaimUsage는 cluster manager가 변경될 때 늘어나는 사용량을 계산한다.
+func updateClaimUsage(clm, updated *clusterV1alpha1.ClusterManager) claimV1alpha1.ClusterQuotaUsage {
+	before, after := usageOfClusterManager(clm), usageOfClusterManager(updated)
+	return claimV1alpha1.ClusterQuotaUsage{
+		MasterNum: after.MasterNum - before.MasterNum,
+		WorkerNum: after.WorkerNum - before.WorkerNum,
+		CpuNum:    after.CpuNum - before.CpuNum,
+		MemSize:   after.MemSize - before.MemSize,
+	}
+}
+
+// quotaAppliesTo는 quota가 cluster claim에 적용되는지 확인한다.
+// group은 cluster claim을 생성할 때 webhook이 기록한 생성자의 group으로 확인하며,
+// 생성자의 group을 알 수 없는 경우(claim이 삭제되었거나 webhook 도입 전에 생성된 경우) 모든 quota를 적용한다.
+func quotaAppliesTo(quota *claimV1alpha1.ClusterQuota, cc *claimV1alpha1.ClusterClaim) bool {
+	if len(quota.Spec.Groups) == 0 || cc == nil {
+		return true
+	}
+	if _, ok := cc.Annotations[claimV1alpha1.AnnotationKeyClaimCreatorGroups]; !ok {
+		return true
+	}
+	return containsAny(quota.Spec.Groups, claimV1alpha1.GetClaimCreatorGroups(cc))
+}
+
+// quotaUsed는 claim 사용량 중 quota가 적용되는 사용량의 합을 계산한다.
+func quotaUsed(quota *claimV1alpha1.ClusterQuota, usages []claimUsage) claimV1alpha1.ClusterQuotaUsage {
+	used := claimV1alpha1.ClusterQuotaUsage{}
+	for _, u := range usages {
+		if quotaAppliesTo(quota, u.claim) {
+			used.Add(u.usage)
+		}
+	}
+	return used
+}
+
+// quotaFieldPaths는 quota를 초과한 경우 error를 표시할 field
+type quotaFieldPaths struct {
+	clusters  *field.Path
+	masterNum *field.Path
+	workerNum *field.Path
+	resources *field.Path
+}
+
+// checkQuota는 사용량에 요청량을 더한 값이 quota를 초과하는지 검사한다.
+// 요청량이 0 이하인 항목은 검사하지 않는다.
+func checkQuota(quota *claimV1alpha1.ClusterQuota, used, requested claimV1alpha1.ClusterQuotaUsage, paths quotaFieldPaths) field.ErrorList {
+	errList := field.ErrorList{}
+	check := func(path *field.Path, name string, limit *int, used, requested int) {
+		if limit == nil || requested <= 0 || used+requested <= *limit {
+			return
+		}
+		errList = append(errList, field.Forbidden(path,
+			fmt.Sprintf("exceeded ClusterQuota [%s]: requested %s: %d, used: %d, limited: %d",
+				quota.Name, name, requested, used, *limit)))
+	}
+
+	hard := quota.Spec.Hard
+	check(paths.clusters, "clusters", hard.Clusters, used.Clusters, requested.Clusters)
+	check(paths.masterNum, "masterNum", hard.MasterNum, used.MasterNum, requested.MasterNum)
+	check(paths.workerNum, "workerNum", hard.WorkerNum, used.WorkerNum, requested.WorkerNum)
+	check(paths.resources, "cpuNum", hard.CpuNum, used.CpuNum, requested.CpuNum)
+	check(paths.resources, "memSize", hard.MemSize, used.MemSize, requested.MemSize)
+	return errList
+}
+
+// nodeTypeField는 이름으로 지정한 VM 사양과 이를 지정한 field
+type nodeTypeField struct {
+	path     *field.Path
+	nodeType string
+}
+
+// checkNodeTypes는 quota에 cpu, memory 제한이 있는 경우 resource를 계산할 수 없는 VM 사양을 거부한다.
+// resource를 계산할 수 없는 VM 사양은 0으로 계산되어 quota를 검사할 수 없다.
+func checkNodeTypes(quota *claimV1alpha1.ClusterQuota, providerName string, fields []nodeTypeField) field.ErrorList {
+	if quota.Spec.Hard.CpuNum == nil && quota.Spec.Hard.MemSize == nil {
+		return nil
+	}
+	errList := field.ErrorList{}
+	for _, f := range fields {
+		if !provider.HasNodeResources(providerName, f.nodeType) {
+			errList = append(errList, field.Invalid(f.path, f.nodeType,
+				fmt.Sprintf("resources of the node type are unknown, so it cannot be checked against ClusterQuota [%s]", quota.Name)))
+		}
+	}
+	return errList
+}
+
+// claimNodeTypes는 cluster claim으로 생성할 cluster의 VM 사양 이름과 field를 반환한다.
+func claimNodeTypes(clm *clusterV1alpha1.ClusterManager) []nodeTypeField {
+	if strings.ToUpper(clm.Spec.Provider) != util.ProviderAws {
+		return nil
+	}
+	awsPath := field.NewPath("spec", "providerAwsSpec")
+	fields := []nodeTypeField{
+		{path: awsPath.Child("masterType"), nodeType: clm.AwsSpec.MasterType},
+		{path: awsPath.Child("workerType"), nodeType: clm.AwsSpec.WorkerType},
+	}
+	for i, pool := range clm.Spec.WorkerPools {
+		if pool.InstanceType != "" {
+			fields = append(fields, nodeTypeField{
+				path:     field.NewPath("spec", "workerPools").Index(i).Child("instanceType"),
+				nodeType: pool.InstanceType,
+			})
+		}
+	}
+	return fields
+}
+
+// updateClaimNodeTypes는 cluster update claim으로 node가 늘어나는 VM 사양 이름과 field를 반환한다.
+func updateClaimNodeTypes(cuc *claimV1alpha1.ClusterUpdateClaim, clm, updated *clusterV1alpha1.ClusterManager) []nodeTypeField {
+	if strings.ToUpper(clm.Spec.Provider) != util.ProviderAws {
+		return nil
+	}
+	fields := []nodeTypeField{}
+	if updated.Spec.MasterNum > clm.Spec.MasterNum {
+		fields = append(fields, nodeTypeField{path: field.NewPath("spec", "updatedMasterNum"), nodeType: clm.AwsSpec.MasterType})
+	}
+	if updated.Spec.WorkerNum > clm.Spec.WorkerNum {
+		fields = append(fields, nodeTypeField{path: field.NewPath("spec", "updatedWorkerNum"), nodeType: clm.AwsSpec.WorkerType})
+	}
+	for i, updatedPool := range cuc.Spec.UpdatedWorkerPools {
+		pool := updated.GetWorkerPool(updatedPool.Name)
+		replicas := 0
+		if current := clm.GetWorkerPool(updatedPool.Name); current != nil {
+			replicas = current.Replicas
+		}
+		if pool == nil || pool.Replicas <= replicas {
+			continue
+		}
+		path := field.NewPath("spec", "updatedWorkerPools").Index(i)
+		if updatedPool.InstanceType != "" {
+			path = path.Child("instanceType")
+		}
+		fields = append(fields, nodeTypeField{path: path, nodeType: provider.DefaultString(pool.InstanceType, clm.AwsSpec.WorkerType)})
+	}
+	return fields
+}
+
+// ClusterQuotaValidator는 cluster claim, cluster update claim이 ClusterQuota를 초과하는지 검증한다.
+// apis package에서는 client를 사용할 수 없으므로 webhook에서 사용할 수 있도록 manager를 시작할 때 설정한다.
+type ClusterQuotaValidator struct {
+	client.Client
+}
+
+// ValidateClusterClaim은 cluster claim으로 생성할 cluster가 quota를 초과하는지 검사한다.
+func (v *ClusterQuotaValidator) ValidateClusterClaim(cc *claimV1alpha1.ClusterClaim) field.ErrorList {
+	ctx := context.TODO()
+	quotaList := &claimV1alpha1.ClusterQuotaList{}
+	if err := v.List(ctx, quotaList, client.InNamespace(cc.Namespace)); err != nil {
+		return field.ErrorList{field.InternalError(field.NewPath("spec"), err)}
+	}
+
+	quotas := []*claimV1alpha1.ClusterQuota{}
+	for i := range quotaList.Items {
+		if quotaAppliesTo(&quotaList.Items[i], cc) {
+			quotas = append(quotas, &quotaList.Items[i])
+		}
+	}
+	if len(quotas) == 0 {
+		return nil
+	}
+
+	clm, _, err := convertClusterClaim(v.Client, cc)
+	if err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("spec"), cc.Spec.ClusterName,
+			"failed to calculate usage of ClusterQuota: "+err.Error())}
+	}
+	requested := usageOfClusterManager(&clm)
+
+	usages, err := listQuotaUsages(ctx, v.Client, cc.Namespace, cc)
+	if err != nil {
+		return field.ErrorList{field.InternalError(field.NewPath("spec"), err)}
+	}
+
+	paths := quotaFieldPaths{
+		clusters:  field.NewPath("spec", "clusterName"),
+		masterNum: field.NewPath("spec", "masterNum"),
+		workerNum: field.NewPath("spec", "workerNum"),
+		resources: field.NewPath("spec"),
+	}
+	nodeTypes := claimNodeTypes(&clm)
+	errList := field.ErrorList{}
+	for _, quota := range quotas {
+		if len(quota.Spec.Providers) > 0 && !containsString(quota.Spec.Providers, clm.Spec.Provider) {
+			errList = append(errList, field.NotSupported(field.NewPath("spec", "provider"), clm.Spec.Provider, quota.Spec.Providers))
+		}
+		errList = append(errList, checkNodeTypes(quota, clm.Spec.Provider, nodeTypes)...)
+		errList = append(errList, checkQuota(quota, quotaUsed(quota, usages), requested, paths)...)
+	}
+	return errList
+}
+
+// ValidateClusterUpdateClaim은 cluster update claim으로 늘어나는 node가 quota를 초과하는지 검사한다.
+func (v *ClusterQuotaValidator) ValidateClusterUpdateClaim(cuc *claimV1alpha1.ClusterUpdateClaim) field.ErrorList {
+	ctx := context.TODO()
+	quotaList := &claimV1alpha1.ClusterQuotaList{}
+	if err := v.List(ctx, quotaList, client.InNamespace(cuc.Namespace)); err != nil {
+		return field.ErrorList{field.InternalError(field.NewPath("spec"), err)}
+	}
+	if len(quotaList.Items) == 0 {
+		return nil
+	}
+
+	// cluster가 없는 경우는 controller에서 처리한다.
+	clm := &clusterV1alpha1.ClusterManager{}
+	key := types.NamespacedName{Name: cuc.Spec.ClusterName, Namespace: cuc.Namespace}
+	if err := v.Get(ctx, key, clm); errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return field.ErrorList{field.InternalError(field.NewPath("spec", "clusterName"), err)}
+	}
+	if clm.GetClusterType() != clusterV1alpha1.ClusterTypeCreated {
+		return nil
+	}
+
+	cc, err := clusterClaimOf(ctx, v.Client, clm)
+	if err != nil {
+		return field.ErrorList{field.InternalError(field.NewPath("spec", "clusterName"), err)}
+	}
+
+	updated := updatedClusterManager(clm, cuc)
+	requested := updateClaimUsage(clm, updated)
+
+	// 승인을 기다리는 다른 cluster update claim이 늘리는 사용량도 포함한다.
+	usages, err := listQuotaUsages(ctx, v.Client, cuc.Namespace, cuc)
+	if err != nil {
+		return field.ErrorList{field.InternalError(field.NewPath("spec"), err)}
+	}
+
+	paths := quotaFieldPaths{
+		clusters:  field.NewPath("spec", "clusterName"),
+		masterNum: field.NewPath("spec", "updatedMasterNum"),
+		workerNum: field.NewPath("spec", "updatedWorkerNum"),
+		resources: field.NewPath("spec"),
+	}
+	nodeTypes := updateClaimNodeTypes(cuc, clm, updated)
+	errList := field.ErrorList{}
+	for i := range quotaList.Items {
+		quota := &quotaList.Items[i]
+		if !quotaAppliesTo(quota, cc) {
+			continue
+		}
+		errList = append(errList, checkNodeTypes(quota, clm.Spec.Provider, nodeTypes)...)
+		errList = append(errList, checkQuota(quota, quotaUsed(quota, usages), requested, paths)...)
+	}
+	return errList
+}
@@ -0,0 +1,116 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	claimV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/claim/v1alpha1"
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// ClusterQuotaReconciler reconciles a ClusterQuota object
+type ClusterQuotaReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=claim.tmax.io,resources=clusterquotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups=claim.tmax.io,resources=clusterquotas/status,verbs=get;update;patch
+
+// cluster claim, cluster manager가 변경되면 namespace의 cluster quota 사용량을 다시 계산하여 status에 기록한다.
+func (r *ClusterQuotaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("ClusterQuota", req.NamespacedName)
+
+	quota := &claimV1alpha1.ClusterQuota{}
+	if err := r.Client.Get(context.TODO(), req.NamespacedName, quota); errors.IsNotFound(err) {
+		log.Info("ClusterQuota resource not found. Ignoring since object must be deleted")
+		return ctrl.Result{}, nil
+	} else if err != nil {
+		log.Error(err, "Failed to get ClusterQuota")
+		return ctrl.Result{}, err
+	}
+
+	usages, err := listQuotaUsages(context.TODO(), r.Client, quota.Namespace, nil)
+	if err != nil {
+		log.Error(err, "Failed to calculate usage of ClusterQuota")
+		return ctrl.Result{}, err
+	}
+
+	used := quotaUsed(quota, usages)
+	if quota.Status.LastUpdatedTime != nil && reflect.DeepEqual(quota.Status.Used, used) {
+		return ctrl.Result{}, nil
+	}
+
+	before := quota.DeepCopy()
+	now := metaV1.Now()
+	quota.Status.Used = used
+	quota.Status.LastUpdatedTime = &now
+	if err := r.Status().Patch(context.TODO(), quota, client.MergeFrom(before)); err != nil {
+		log.Error(err, "Failed to update ClusterQuota status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// RequeueClusterQuotasForObject는 object와 같은 namespace의 모든 cluster quota를 reconcile loop로 보낸다.
+func (r *ClusterQuotaReconciler) RequeueClusterQuotasForObject(o client.Object) []ctrl.Request {
+	quotaList := &claimV1alpha1.ClusterQuotaList{}
+	if err := r.List(context.TODO(), quotaList, client.InNamespace(o.GetNamespace())); err != nil {
+		r.Log.Error(err, "Failed to list ClusterQuotas", "namespace", o.GetNamespace())
+		return nil
+	}
+
+	reqs := []ctrl.Request{}
+	for _, quota := range quotaList.Items {
+		reqs = append(reqs, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&quota)})
+	}
+	return reqs
+}
+
+func (r *ClusterQuotaReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&claimV1alpha1.ClusterQuota{},
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(
+			&source.Kind{Type: &claimV1alpha1.ClusterClaim{}},
+			handler.EnqueueRequestsFromMapFunc(r.RequeueClusterQuotasForObject),
+		).
+		Watches(
+			&source.Kind{Type: &claimV1alpha1.ClusterUpdateClaim{}},
+			handler.EnqueueRequestsFromMapFunc(r.RequeueClusterQuotasForObject),
+		).
+		// status collector 등이 주기적으로 변경하는 status는 사용량과 관계없으므로 spec 변경만 확인한다.
+		Watches(
+			&source.Kind{Type: &clusterV1alpha1.ClusterManager{}},
+			handler.EnqueueRequestsFromMapFunc(r.RequeueClusterQuotasForObject),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Complete(r)
+}
@@ -0,0 +1,212 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	claimV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/claim/v1alpha1"
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	_ "github.com/tmax-cloud/hypercloud-multi-operator/controllers/provider/docker"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newQuotaTestClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	if err := claimV1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := clusterV1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func newTestClusterQuota(workerNum int) *claimV1alpha1.ClusterQuota {
+	return &claimV1alpha1.ClusterQuota{
+		ObjectMeta: metaV1.ObjectMeta{Name: "quota", Namespace: "default"},
+		Spec: claimV1alpha1.ClusterQuotaSpec{
+			Hard: claimV1alpha1.ClusterQuotaLimits{WorkerNum: &workerNum},
+		},
+	}
+}
+
+func newTestClusterClaim(name string, workerNum int, phase claimV1alpha1.ClusterClaimPhase) *claimV1alpha1.ClusterClaim {
+	return &claimV1alpha1.ClusterClaim{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: claimV1alpha1.ClusterClaimSpec{
+			ClusterName: name,
+			Provider:    clusterV1alpha1.ProviderDocker,
+			Version:     "v1.22.2",
+			MasterNum:   1,
+			WorkerNum:   workerNum,
+		},
+		Status: claimV1alpha1.ClusterClaimStatus{Phase: phase},
+	}
+}
+
+func newTestClusterManager(cc *claimV1alpha1.ClusterClaim) *clusterV1alpha1.ClusterManager {
+	return &clusterV1alpha1.ClusterManager{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      cc.Spec.ClusterName,
+			Namespace: cc.Namespace,
+			Labels: map[string]string{
+				clusterV1alpha1.LabelKeyClmClusterType: clusterV1alpha1.ClusterTypeCreated,
+				clusterV1alpha1.LabelKeyClcName:        cc.Name,
+			},
+		},
+		Spec: clusterV1alpha1.ClusterManagerSpec{
+			Provider:  cc.Spec.Provider,
+			Version:   cc.Spec.Version,
+			MasterNum: cc.Spec.MasterNum,
+			WorkerNum: cc.Spec.WorkerNum,
+		},
+	}
+}
+
+func newTestScaleClaim(name, clusterName string, workerNum int, phase claimV1alpha1.ClusterUpdateClaimPhase) *claimV1alpha1.ClusterUpdateClaim {
+	return &claimV1alpha1.ClusterUpdateClaim{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: claimV1alpha1.ClusterUpdateClaimSpec{
+			ClusterName:      clusterName,
+			UpdatedWorkerNum: workerNum,
+		},
+		Status: claimV1alpha1.ClusterUpdateClaimStatus{Phase: phase},
+	}
+}
+
+// 승인을 기다리는 claim의 spec을 변경하는 경우에도 quota를 검사해야 한다.
+func TestClusterClaimValidateUpdateQuota(t *testing.T) {
+	pending := newTestClusterClaim("pending", 2, claimV1alpha1.ClusterClaimPhaseAwaiting)
+	c := newQuotaTestClient(t,
+		newTestClusterQuota(5),
+		newTestClusterClaim("other", 2, claimV1alpha1.ClusterClaimPhaseAwaiting),
+		pending,
+	)
+	validator := &ClusterQuotaValidator{Client: c}
+	defer func(f func(*claimV1alpha1.ClusterClaim) field.ErrorList) {
+		claimV1alpha1.ClusterClaimQuotaValidator = f
+	}(claimV1alpha1.ClusterClaimQuotaValidator)
+	claimV1alpha1.ClusterClaimQuotaValidator = validator.ValidateClusterClaim
+
+	tests := []struct {
+		name      string
+		workerNum int
+		wantErr   bool
+	}{
+		{name: "within quota", workerNum: 3, wantErr: false},
+		{name: "exceeds quota", workerNum: 4, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := pending.DeepCopy()
+			updated.Spec.WorkerNum = tt.workerNum
+			err := updated.ValidateUpdate(pending)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// 승인을 기다리거나 승인되었지만 반영되지 않은 cluster update claim이 늘리는 node도 사용량에 포함해야 한다.
+func TestValidateClusterUpdateClaimPendingUsage(t *testing.T) {
+	cc := newTestClusterClaim("prod", 2, claimV1alpha1.ClusterClaimPhaseApproved)
+	clm := newTestClusterManager(cc)
+
+	applied := newTestScaleClaim("applied", "prod", 4, claimV1alpha1.ClusterUpdateClaimPhaseApproved)
+	applied.Status.Reason = claimV1alpha1.ClusterUpdateClaimReasonAdminApproved
+	unapplied := newTestScaleClaim("unapplied", "prod", 4, claimV1alpha1.ClusterUpdateClaimPhaseApproved)
+	dryRun := newTestScaleClaim("dry-run", "prod", 4, claimV1alpha1.ClusterUpdateClaimPhaseAwaiting)
+	dryRun.Spec.DryRun = true
+
+	tests := []struct {
+		name    string
+		others  []client.Object
+		wantErr bool
+	}{
+		{name: "no pending update claim", wantErr: false},
+		{name: "awaiting update claim", others: []client.Object{newTestScaleClaim("awaiting", "prod", 4, claimV1alpha1.ClusterUpdateClaimPhaseAwaiting)}, wantErr: true},
+		{name: "approved but not applied", others: []client.Object{unapplied}, wantErr: true},
+		{name: "applied update claim", others: []client.Object{applied}, wantErr: false},
+		{name: "rejected update claim", others: []client.Object{newTestScaleClaim("rejected", "prod", 4, claimV1alpha1.ClusterUpdateClaimPhaseRejected)}, wantErr: false},
+		{name: "dry run update claim", others: []client.Object{dryRun}, wantErr: false},
+		{name: "scale in does not free quota", others: []client.Object{newTestScaleClaim("scale-in", "prod", 1, claimV1alpha1.ClusterUpdateClaimPhaseAwaiting)}, wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs := append([]client.Object{newTestClusterQuota(5), cc.DeepCopy(), clm.DeepCopy()}, tt.others...)
+			validator := &ClusterQuotaValidator{Client: newQuotaTestClient(t, objs...)}
+
+			// 현재 worker 2개에서 4개로 늘리므로 다른 claim이 늘리는 node가 없어야 quota 5를 넘지 않는다.
+			cuc := newTestScaleClaim("new", "prod", 4, "")
+			errList := validator.ValidateClusterUpdateClaim(cuc)
+			if (len(errList) > 0) != tt.wantErr {
+				t.Errorf("ValidateClusterUpdateClaim() = %v, wantErr %v", errList, tt.wantErr)
+			}
+		})
+	}
+}
+
+// dry run인 claim은 사용량에 포함되지 않으므로 dry run을 해제할 때 quota를 검사해야 한다.
+func TestDryRunReleaseQuota(t *testing.T) {
+	defer func(cc func(*claimV1alpha1.ClusterClaim) field.ErrorList, cuc func(*claimV1alpha1.ClusterUpdateClaim) field.ErrorList) {
+		claimV1alpha1.ClusterClaimQuotaValidator, claimV1alpha1.ClusterUpdateClaimQuotaValidator = cc, cuc
+	}(claimV1alpha1.ClusterClaimQuotaValidator, claimV1alpha1.ClusterUpdateClaimQuotaValidator)
+
+	prod := newTestClusterClaim("prod", 2, claimV1alpha1.ClusterClaimPhaseApproved)
+	tests := []struct {
+		name      string
+		workerNum int
+		wantErr   bool
+	}{
+		{name: "within quota", workerNum: 3, wantErr: false},
+		{name: "exceeds quota", workerNum: 4, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run("ClusterClaim "+tt.name, func(t *testing.T) {
+			dryRun := newTestClusterClaim("dry-run", tt.workerNum, claimV1alpha1.ClusterClaimPhaseAwaiting)
+			dryRun.Spec.DryRun = true
+			validator := &ClusterQuotaValidator{Client: newQuotaTestClient(t, newTestClusterQuota(5), prod.DeepCopy(), dryRun)}
+			claimV1alpha1.ClusterClaimQuotaValidator = validator.ValidateClusterClaim
+
+			released := dryRun.DeepCopy()
+			released.Spec.DryRun = false
+			if err := released.ValidateUpdate(dryRun); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+
+		t.Run("ClusterUpdateClaim "+tt.name, func(t *testing.T) {
+			// 현재 worker 2개에서 workerNum만큼 늘리므로 cluster claim과 같은 사용량이 된다.
+			dryRun := newTestScaleClaim("dry-run", "prod", 2+tt.workerNum, claimV1alpha1.ClusterUpdateClaimPhaseAwaiting)
+			dryRun.Spec.DryRun = true
+			validator := &ClusterQuotaValidator{Client: newQuotaTestClient(t,
+				newTestClusterQuota(5), prod.DeepCopy(), newTestClusterManager(prod), dryRun)}
+			claimV1alpha1.ClusterUpdateClaimQuotaValidator = validator.ValidateClusterUpdateClaim
+
+			released := dryRun.DeepCopy()
+			released.Spec.DryRun = false
+			if err := released.ValidateUpdate(dryRun); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
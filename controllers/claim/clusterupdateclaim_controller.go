@@ -17,12 +17,15 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 	claimV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/claim/v1alpha1"
 	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,8 +38,11 @@ import (
 // ClusterClaimReconciler reconciles a ClusterClaim object
 type ClusterUpdateClaimReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// cache를 거치지 않고 조회할 때 사용한다.
+	APIReader client.Reader
 }
 
 const (
@@ -72,13 +78,25 @@ func (r *ClusterUpdateClaimReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, err
 	}
 
+	before := cuc.Status.DeepCopy()
+
 	defer func() {
 		if err := patchHelper.Patch(context.TODO(), cuc); err != nil {
 			log.Error(err, "Failed to patch clusterupdateclaim")
 			reterr = err
+		} else {
+			r.recordStatusEvent(before, cuc)
 		}
 	}()
 
+	// webhook이 status에 기록한 승인/거절한 사용자를 annotation에도 남긴다.
+	if cuc.Status.Approver != "" {
+		if cuc.Annotations == nil {
+			cuc.Annotations = map[string]string{}
+		}
+		cuc.Annotations[claimV1alpha1.AnnotationKeyClaimApprover] = cuc.Status.Approver
+	}
+
 	return r.reconcile(ctx, cuc)
 }
 
@@ -114,21 +132,55 @@ func (r *ClusterUpdateClaimReconciler) reconcile(ctx context.Context, cuc *claim
 
 	log.Info(fmt.Sprintf("Found clustermanager [%s]. Start clusterupdateclaim reconcile phase", cuc.Spec.ClusterName))
 
+	// upgrade할 version은 승인되기 전까지 reconcile할 때마다 현재 version과 비교하여 검사한다.
+	// cluster의 version이 변경되어 upgrade할 수 없게 된 claim도 error로 표시한다.
+	if !cuc.IsPhaseApproved() && cuc.GetUpdateType() == claimV1alpha1.ClusterUpdateTypeUpgrade {
+		if err := validateUpgradeVersion(clm, cuc); err != nil {
+			log.Info("Invalid version to upgrade", "reason", err.Error())
+			cuc.Status.SetTypedPhase(claimV1alpha1.ClusterUpdateClaimPhaseError)
+			cuc.Status.SetTypedReason(claimV1alpha1.ClusterUpdateClaimReason(err.Error()))
+			return ctrl.Result{}, nil
+		}
+	}
+
 	r.SetupClaim(cuc, clm)
 
+	// dry run인 claim은 변경될 내용만 렌더링하고 승인을 처리하지 않는다.
+	if cuc.Spec.DryRun && cuc.IsPhaseAwaiting() {
+		return ctrl.Result{}, r.reconcileDryRun(ctx, clm, cuc)
+	}
+
 	if cuc.IsPhaseError() || cuc.IsPhaseAwaiting() {
 		return ctrl.Result{}, nil
 	}
 
 	if cuc.IsPhaseApproved() {
-		if err := r.CheckValidClaim(clm, cuc); err != nil {
+		// paused인 cluster에는 반영하지 않고 resume될 때까지 기다린다.
+		if clm.Spec.Paused {
+			log.Info(fmt.Sprintf("Clustermanager [%s] is paused. Wait for resume", cuc.Spec.ClusterName))
+			cuc.Status.SetTypedReason(claimV1alpha1.ClusterUpdateClaimReasonClusterPaused)
+			return ctrl.Result{RequeueAfter: requeueAfter1Minute}, nil
+		}
+
+		// update type별로 검사하고 cluster manager에 반영한다.
+		check, apply := r.CheckValidClaim, r.UpdateNodeNum
+		switch cuc.GetUpdateType() {
+		case claimV1alpha1.ClusterUpdateTypeExtension:
+			check, apply = r.CheckValidExtension, r.ExtendExpiry
+		case claimV1alpha1.ClusterUpdateTypeUpgrade:
+			check, apply = r.CheckValidUpgrade, r.UpgradeVersion
+		case claimV1alpha1.ClusterUpdateTypeDeletionProtection:
+			check, apply = r.CheckValidDeletionProtection, r.UpdateDeletionProtection
+		}
+
+		if err := check(clm, cuc); err != nil {
 			log.Error(err, "Failed to approve")
 			cuc.Status.SetTypedPhase(claimV1alpha1.ClusterUpdateClaimPhaseError)
-			cuc.Status.SetTypedReason(claimV1alpha1.ClusterUpdateClaimReasonConcurruencyError)
+			cuc.Status.SetTypedReason(claimV1alpha1.ClusterUpdateClaimReason(err.Error()))
 			return ctrl.Result{}, nil
 		}
 
-		if err := r.UpdateNodeNum(clm, cuc); err != nil {
+		if err := apply(clm, cuc); err != nil {
 			log.Error(err, "Failed to approve")
 			cuc.Status.SetTypedPhase(claimV1alpha1.ClusterUpdateClaimPhaseError)
 			cuc.Status.SetTypedReason(claimV1alpha1.ClusterUpdateClaimReason(err.Error()))
@@ -136,6 +188,8 @@ func (r *ClusterUpdateClaimReconciler) reconcile(ctx context.Context, cuc *claim
 		}
 
 		log.Info("Approved clusterupdateclaim")
+		r.Recorder.Eventf(clm, coreV1.EventTypeNormal, "UpdateClaimApplied",
+			"ClusterUpdateClaim %s of %s type is applied", cuc.Name, cuc.GetUpdateType())
 		cuc.Status.SetTypedPhase(claimV1alpha1.ClusterUpdateClaimPhaseApproved)
 		cuc.Status.SetTypedReason(claimV1alpha1.ClusterUpdateClaimReasonAdminApproved)
 		return ctrl.Result{}, nil
@@ -147,6 +201,21 @@ func (r *ClusterUpdateClaimReconciler) reconcile(ctx context.Context, cuc *claim
 	return ctrl.Result{}, nil
 }
 
+// recordStatusEvent는 phase 또는 reason이 바뀐 경우 event를 남긴다.
+// 관리자가 승인한 claim을 반영하면 phase는 그대로 approved이고 reason만 바뀐다.
+func (r *ClusterUpdateClaimReconciler) recordStatusEvent(before *claimV1alpha1.ClusterUpdateClaimStatus, cuc *claimV1alpha1.ClusterUpdateClaim) {
+	if cuc.Status.Phase == "" || (before.Phase == cuc.Status.Phase && before.Reason == cuc.Status.Reason) {
+		return
+	}
+
+	eventType := coreV1.EventTypeNormal
+	if cuc.IsPhaseError() || cuc.IsPhaseRejected() {
+		eventType = coreV1.EventTypeWarning
+	}
+	reason := strings.ReplaceAll(string(cuc.Status.Phase), " ", "")
+	r.Recorder.Event(cuc, eventType, reason, string(cuc.Status.Reason))
+}
+
 func (r *ClusterUpdateClaimReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	controller, err := ctrl.NewControllerManagedBy(mgr).
 		For(&claimV1alpha1.ClusterUpdateClaim{}).
@@ -163,7 +232,12 @@ func (r *ClusterUpdateClaimReconciler) SetupWithManager(mgr ctrl.Manager) error
 					oc := e.ObjectOld.(*claimV1alpha1.ClusterUpdateClaim)
 					nc := e.ObjectNew.(*claimV1alpha1.ClusterUpdateClaim)
 
-					if oc.IsPhaseApproved() || (!oc.IsPhaseRejected() && nc.IsPhaseRejected()) {
+					if oc.IsPhaseApproved() {
+						return false
+					}
+					// 거절된 claim을 reconcile하면 awaiting으로 돌아가므로, event만 남기고 reconcile하지 않는다.
+					if !oc.IsPhaseRejected() && nc.IsPhaseRejected() {
+						r.Recorder.Eventf(nc, coreV1.EventTypeWarning, "Rejected", "ClusterUpdateClaim is rejected: %s", nc.Status.Reason)
 						return false
 					}
 
@@ -3,12 +3,16 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	claimV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/claim/v1alpha1"
 	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/util"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	// "k8s.io/apimachinery/pkg/api/errors"
 )
 
 // updateclaim에 대한 낙관적 동시성 처리
@@ -25,6 +29,120 @@ func (r *ClusterUpdateClaimReconciler) CheckValidClaim(clm *clusterV1alpha1.Clus
 	if statusMasterNum != realMasterNum || statusWorkerNum != realWorkerNum {
 		return fmt.Errorf(string(claimV1alpha1.ClusterUpdateClaimReasonConcurruencyError))
 	}
+
+	// worker pool별 노드 수도 동일해야 한다.
+	if len(cuc.Status.CurrentWorkerPools) != len(clm.Spec.WorkerPools) {
+		return fmt.Errorf(string(claimV1alpha1.ClusterUpdateClaimReasonConcurruencyError))
+	}
+	for _, pool := range clm.Spec.WorkerPools {
+		if replicas, ok := cuc.Status.CurrentWorkerPools[pool.Name]; !ok || replicas != pool.Replicas {
+			return fmt.Errorf(string(claimV1alpha1.ClusterUpdateClaimReasonConcurruencyError))
+		}
+	}
+	return nil
+}
+
+// 만료 시각 연장에 대한 낙관적 동시성 처리
+func (r *ClusterUpdateClaimReconciler) CheckValidExtension(clm *clusterV1alpha1.ClusterManager, cuc *claimV1alpha1.ClusterUpdateClaim) error {
+	if clm.Spec.ExpiresAt == nil {
+		return fmt.Errorf(string(claimV1alpha1.ClusterUpdateClaimReasonNoExpiry))
+	}
+	if cuc.Status.CurrentExpiresAt == nil || !cuc.Status.CurrentExpiresAt.Equal(clm.Spec.ExpiresAt) {
+		return fmt.Errorf(string(claimV1alpha1.ClusterUpdateClaimReasonExpiryConcurrency))
+	}
+	return nil
+}
+
+// 만료 시각을 연장할 때 사용하는 메소드
+func (r *ClusterUpdateClaimReconciler) ExtendExpiry(clm *clusterV1alpha1.ClusterManager, cuc *claimV1alpha1.ClusterUpdateClaim) error {
+	expiresAt := metav1.NewTime(clm.Spec.ExpiresAt.Add(cuc.Spec.ExtendTTL.Duration))
+	clm.Spec.ExpiresAt = &expiresAt
+
+	if err := r.Update(context.TODO(), clm); err != nil {
+		return err
+	}
+	return nil
+}
+
+// version upgrade에 대한 낙관적 동시성 처리
+func (r *ClusterUpdateClaimReconciler) CheckValidUpgrade(clm *clusterV1alpha1.ClusterManager, cuc *claimV1alpha1.ClusterUpdateClaim) error {
+	if cuc.Status.CurrentVersion != clm.Spec.Version {
+		return fmt.Errorf(string(claimV1alpha1.ClusterUpdateClaimReasonVersionConcurrency))
+	}
+	return validateUpgradeVersion(clm, cuc)
+}
+
+// validateUpgradeVersion은 현재 version에서 upgrade할 수 있는 version인지, provider에 맞게 template을 지정했는지 검사한다.
+func validateUpgradeVersion(clm *clusterV1alpha1.ClusterManager, cuc *claimV1alpha1.ClusterUpdateClaim) error {
+	if err := clusterV1alpha1.ValidateVersionUpgrade(clm.Spec.Version, cuc.Spec.UpdatedVersion); err != nil {
+		return err
+	}
+
+	// vsphere는 version별로 VM template이 다르므로 함께 변경해야 한다.
+	isVsphere := strings.ToUpper(clm.Spec.Provider) == util.ProviderVsphere
+	if isVsphere && cuc.Spec.UpdatedVsphereTemplate == "" {
+		return fmt.Errorf("updatedVsphereTemplate is required to upgrade vSphere cluster")
+	} else if !isVsphere && cuc.Spec.UpdatedVsphereTemplate != "" {
+		return fmt.Errorf("updatedVsphereTemplate can be used only for vSphere cluster")
+	}
+	return nil
+}
+
+// UpgradeVersionValidator는 upgrade할 version을 cluster 생성 요청 시점에 검사할 수 있도록 webhook에서 사용한다.
+// apis package에서는 client를 사용할 수 없으므로 manager를 시작할 때 설정한다.
+type UpgradeVersionValidator struct {
+	client.Client
+}
+
+// ValidateClusterUpdateClaim은 cluster의 현재 version에서 upgrade할 수 있는 version인지 검사한다.
+func (v *UpgradeVersionValidator) ValidateClusterUpdateClaim(cuc *claimV1alpha1.ClusterUpdateClaim) field.ErrorList {
+	// cluster가 없는 경우는 controller에서 처리한다.
+	clm := &clusterV1alpha1.ClusterManager{}
+	if err := v.Get(context.TODO(), cuc.GetClusterNamespacedName(), clm); errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return field.ErrorList{field.InternalError(field.NewPath("spec", "clusterName"), err)}
+	}
+
+	if err := validateUpgradeVersion(clm, cuc); err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("spec", "updatedVersion"), cuc.Spec.UpdatedVersion, err.Error())}
+	}
+	return nil
+}
+
+// version을 upgrade할 때 사용하는 메소드
+// 실제 upgrade는 cluster manager controller에서 진행한다.
+func (r *ClusterUpdateClaimReconciler) UpgradeVersion(clm *clusterV1alpha1.ClusterManager, cuc *claimV1alpha1.ClusterUpdateClaim) error {
+	clm.SetK8SVersion(cuc.Spec.UpdatedVersion)
+	if cuc.Spec.UpdatedVsphereTemplate != "" {
+		clm.VsphereSpec.VcenterTemplate = cuc.Spec.UpdatedVsphereTemplate
+	}
+
+	if err := r.Update(context.TODO(), clm); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 삭제 보호 변경에 대한 낙관적 동시성 처리
+func (r *ClusterUpdateClaimReconciler) CheckValidDeletionProtection(clm *clusterV1alpha1.ClusterManager, cuc *claimV1alpha1.ClusterUpdateClaim) error {
+	if cuc.Status.CurrentDeletionProtection != clm.IsDeletionProtected() {
+		return fmt.Errorf(string(claimV1alpha1.ClusterUpdateClaimReasonProtectionConcurrency))
+	}
+	return nil
+}
+
+// 삭제 보호를 설정 또는 해제할 때 사용하는 메소드
+// 해제하는 경우 annotation으로 설정된 삭제 보호도 함께 제거한다.
+func (r *ClusterUpdateClaimReconciler) UpdateDeletionProtection(clm *clusterV1alpha1.ClusterManager, cuc *claimV1alpha1.ClusterUpdateClaim) error {
+	clm.Spec.DeletionProtection = *cuc.Spec.UpdatedDeletionProtection
+	if !clm.Spec.DeletionProtection {
+		delete(clm.Annotations, clusterV1alpha1.AnnotationKeyClmDeletionProtection)
+	}
+
+	if err := r.Update(context.TODO(), clm); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -42,6 +160,15 @@ func (r *ClusterUpdateClaimReconciler) UpdateNodeNum(clm *clusterV1alpha1.Cluste
 		clm.Spec.WorkerNum = cuc.Spec.UpdatedWorkerNum
 	}
 
+	// 기존 worker pool은 replicas만 변경하고, 없는 worker pool은 새로 추가한다.
+	for _, updatedPool := range cuc.Spec.UpdatedWorkerPools {
+		if pool := clm.GetWorkerPool(updatedPool.Name); pool != nil {
+			pool.Replicas = updatedPool.Replicas
+		} else {
+			clm.Spec.WorkerPools = append(clm.Spec.WorkerPools, NewWorkerPool(updatedPool))
+		}
+	}
+
 	if err := r.Update(context.TODO(), clm); err != nil {
 		return err
 	}
@@ -85,6 +212,12 @@ func (r *ClusterUpdateClaimReconciler) SetupClaim(clusterUpdateClaim *claimV1alp
 		clusterUpdateClaim.Labels[LabelKeyClmName] = clusterUpdateClaim.Spec.ClusterName
 	}
 
+	// upgrade할 수 없는 version으로 error가 된 claim은 승인할 수 없으므로 awaiting으로 되돌리지 않는다.
+	if clusterUpdateClaim.IsPhaseError() && clusterUpdateClaim.GetUpdateType() == claimV1alpha1.ClusterUpdateTypeUpgrade &&
+		validateUpgradeVersion(clusterManager, clusterUpdateClaim) != nil {
+		return
+	}
+
 	// phase in (공백, error, rejected) 인 경우, awaiting으로 수정
 	if clusterUpdateClaim.IsPhaseEmpty() || clusterUpdateClaim.IsPhaseError() || clusterUpdateClaim.IsPhaseRejected() {
 		clusterUpdateClaim.Status.SetTypedPhase(claimV1alpha1.ClusterUpdateClaimPhaseAwaiting)
@@ -92,6 +225,18 @@ func (r *ClusterUpdateClaimReconciler) SetupClaim(clusterUpdateClaim *claimV1alp
 
 		clusterUpdateClaim.Status.CurrentMasterNum = clusterManager.Spec.MasterNum
 		clusterUpdateClaim.Status.CurrentWorkerNum = clusterManager.Spec.WorkerNum
+		clusterUpdateClaim.Status.CurrentWorkerPools = map[string]int{}
+		for _, pool := range clusterManager.Spec.WorkerPools {
+			clusterUpdateClaim.Status.CurrentWorkerPools[pool.Name] = pool.Replicas
+		}
+		clusterUpdateClaim.Status.CurrentExpiresAt = clusterManager.Spec.ExpiresAt.DeepCopy()
+		clusterUpdateClaim.Status.CurrentVersion = clusterManager.Spec.Version
+		clusterUpdateClaim.Status.CurrentDeletionProtection = clusterManager.IsDeletionProtected()
+
+		// 만료 시각 연장, upgrade, 삭제 보호 변경은 node 수를 변경하지 않는다.
+		if clusterUpdateClaim.GetUpdateType() != claimV1alpha1.ClusterUpdateTypeNodeScale {
+			return
+		}
 
 		if clusterUpdateClaim.Spec.UpdatedMasterNum == 0 {
 			clusterUpdateClaim.Spec.UpdatedMasterNum = clusterManager.Spec.MasterNum
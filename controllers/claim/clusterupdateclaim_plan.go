@@ -0,0 +1,151 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	claimV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/claim/v1alpha1"
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	clusterController "github.com/tmax-cloud/hypercloud-multi-operator/controllers/cluster"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/provider"
+
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// planChange는 claim이 승인되면 변경될 resource의 field
+type planChange struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Field   string `json:"field"`
+	Current string `json:"current"`
+	Updated string `json:"updated"`
+}
+
+func (c planChange) String() string {
+	return fmt.Sprintf("%s %s %s %s -> %s", c.Kind, c.Name, c.Field, c.Current, c.Updated)
+}
+
+// reconcileDryRun은 승인되었을 때 변경될 KubeadmControlPlane과 MachineDeployment를 plan ConfigMap에 기록한다.
+// 새로 추가되는 worker pool은 생성할 template instance도 기록한다.
+func (r *ClusterUpdateClaimReconciler) reconcileDryRun(ctx context.Context, clm *clusterV1alpha1.ClusterManager, cuc *claimV1alpha1.ClusterUpdateClaim) error {
+	if cuc.Status.Plan != nil && cuc.Status.Plan.ObservedGeneration == cuc.Generation {
+		return nil
+	}
+
+	plan := &claimV1alpha1.ClaimPlan{
+		ObservedGeneration: cuc.Generation,
+		Time:               metaV1.Now(),
+	}
+	changes, objects, err := planClusterUpdateClaim(clm, cuc)
+	if err != nil {
+		plan.Message = err.Error()
+		cuc.Status.Plan = plan
+		r.Recorder.Eventf(cuc, coreV1.EventTypeWarning, "PlanFailed", "Failed to render plan: %s", plan.Message)
+		return nil
+	}
+
+	data, err := renderPlanObjects(r.Scheme, objects)
+	if err != nil {
+		return err
+	}
+	out, err := yaml.Marshal(changes)
+	if err != nil {
+		return err
+	}
+	data["changes.yaml"] = string(out)
+	for _, change := range changes {
+		plan.Summary = append(plan.Summary, change.String())
+	}
+
+	if plan.ConfigMap, err = writePlanConfigMap(ctx, r.Client, r.APIReader, r.Scheme, cuc, data); err != nil {
+		return err
+	}
+	cuc.Status.Plan = plan
+	r.Recorder.Eventf(cuc, coreV1.EventTypeNormal, "PlanRendered", "Plan is rendered to ConfigMap %s", plan.ConfigMap)
+	return nil
+}
+
+// planClusterUpdateClaim은 update type별로 cluster manager controller가 변경할 resource를 계산한다.
+// 현재 값은 claim이 awaiting이 될 때 status에 기록된 값을 사용한다.
+func planClusterUpdateClaim(clm *clusterV1alpha1.ClusterManager, cuc *claimV1alpha1.ClusterUpdateClaim) ([]planChange, map[string]client.Object, error) {
+	changes := []planChange{}
+	objects := map[string]client.Object{}
+	kcpName := clm.Name + "-control-plane"
+
+	switch cuc.GetUpdateType() {
+	case claimV1alpha1.ClusterUpdateTypeNodeScale:
+		if cuc.Spec.UpdatedMasterNum != cuc.Status.CurrentMasterNum {
+			changes = append(changes, replicaChange("KubeadmControlPlane", kcpName, cuc.Status.CurrentMasterNum, cuc.Spec.UpdatedMasterNum))
+		}
+		if cuc.Spec.UpdatedWorkerNum != cuc.Status.CurrentWorkerNum {
+			changes = append(changes, replicaChange("MachineDeployment", clm.GetMachineDeploymentName(clusterV1alpha1.DefaultWorkerPoolName),
+				cuc.Status.CurrentWorkerNum, cuc.Spec.UpdatedWorkerNum))
+		}
+
+		var p provider.Provider
+		for _, updatedPool := range cuc.Spec.UpdatedWorkerPools {
+			name := clm.GetMachineDeploymentName(updatedPool.Name)
+			if replicas, ok := cuc.Status.CurrentWorkerPools[updatedPool.Name]; ok {
+				if replicas != updatedPool.Replicas {
+					changes = append(changes, replicaChange("MachineDeployment", name, replicas, updatedPool.Replicas))
+				}
+				continue
+			}
+
+			// 새로 추가되는 worker pool은 template instance로 machinedeployment를 생성한다.
+			if p == nil {
+				var err error
+				if p, err = provider.Get(clm.Spec.Provider); err != nil {
+					return nil, nil, err
+				}
+			}
+			ti, err := clusterController.BuildWorkerPoolTemplateInstance(p, clm, NewWorkerPool(updatedPool))
+			if err != nil {
+				return nil, nil, err
+			}
+			objects["templateinstance-"+updatedPool.Name+".yaml"] = ti
+			changes = append(changes, replicaChange("MachineDeployment", name, 0, updatedPool.Replicas))
+		}
+	case claimV1alpha1.ClusterUpdateTypeUpgrade:
+		current, updated := cuc.Status.CurrentVersion, cuc.Spec.UpdatedVersion
+		changes = append(changes, planChange{Kind: "KubeadmControlPlane", Name: kcpName, Field: "version", Current: current, Updated: updated})
+		for _, pool := range clm.GetWorkerPools() {
+			changes = append(changes, planChange{Kind: "MachineDeployment", Name: clm.GetMachineDeploymentName(pool.Name),
+				Field: "version", Current: current, Updated: updated})
+		}
+	case claimV1alpha1.ClusterUpdateTypeExtension:
+		if cuc.Status.CurrentExpiresAt == nil {
+			return nil, nil, fmt.Errorf(string(claimV1alpha1.ClusterUpdateClaimReasonNoExpiry))
+		}
+		expiresAt := metaV1.NewTime(cuc.Status.CurrentExpiresAt.Add(cuc.Spec.ExtendTTL.Duration))
+		changes = append(changes, planChange{Kind: "ClusterManager", Name: clm.Name, Field: "expiresAt",
+			Current: cuc.Status.CurrentExpiresAt.UTC().Format(time.RFC3339), Updated: expiresAt.UTC().Format(time.RFC3339)})
+	case claimV1alpha1.ClusterUpdateTypeDeletionProtection:
+		changes = append(changes, planChange{Kind: "ClusterManager", Name: clm.Name, Field: "deletionProtection",
+			Current: strconv.FormatBool(cuc.Status.CurrentDeletionProtection), Updated: strconv.FormatBool(*cuc.Spec.UpdatedDeletionProtection)})
+	}
+	return changes, objects, nil
+}
+
+func replicaChange(kind, name string, current, updated int) planChange {
+	return planChange{Kind: kind, Name: name, Field: "replicas", Current: strconv.Itoa(current), Updated: strconv.Itoa(updated)}
+}
@@ -18,11 +18,13 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"reflect"
 	"time"
 
 	"github.com/go-logr/logr"
 	certmanagerV1 "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
 	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/metrics"
 	util "github.com/tmax-cloud/hypercloud-multi-operator/controllers/util"
 	tmaxv1 "github.com/tmax-cloud/template-operator/api/v1"
 	traefikV1alpha1 "github.com/traefik/traefik/v2/pkg/provider/kubernetes/crd/traefik/v1alpha1"
@@ -30,9 +32,11 @@ import (
 	coreV1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
 
 	capiV1alpha3 "sigs.k8s.io/cluster-api/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/util/patch"
@@ -51,8 +55,9 @@ import (
 // ClusterManagerReconciler reconciles a ClusterManager object
 type ClusterManagerReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 const (
@@ -63,9 +68,6 @@ const (
 )
 
 const (
-	// upgrade template
-	CAPI_VSPHERE_UPGRADE_TEMPLATE = "capi-vsphere-upgrade-template"
-
 	// machine을 구분하기 위한 label key
 	CAPI_CLUSTER_LABEL_KEY      = "cluster.x-k8s.io/cluster-name"
 	CAPI_CONTROLPLANE_LABEL_KEY = "cluster.x-k8s.io/control-plane"
@@ -74,7 +76,7 @@ const (
 
 // +kubebuilder:rbac:groups=cluster.tmax.io,resources=clustermanagers,verbs=create;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups=cluster.tmax.io,resources=clustermanagers/status,verbs=get;list;patch;update;watch
-// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters,verbs=get;list;patch;update;watch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinedeployments,verbs=create;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinedeployments/status,verbs=get;list;patch;update;watch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines,verbs=get;list;patch;update;watch
@@ -83,6 +85,7 @@ const (
 // +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=kubeadmcontrolplanes,verbs=create;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=kubeadmcontrolplanes/status,verbs=get;list;patch;update;watch
 // +kubebuilder:rbac:groups=tmax.io,resources=templateinstances,verbs=create;delete;get;list;patch;update;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=dockermachinetemplates,verbs=create;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=create;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=create;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups="",resources=services;endpoints,verbs=create;delete;get;list;patch;update;watch
@@ -110,6 +113,7 @@ func (r *ClusterManagerReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	before := clusterManager.DeepCopy()
 
 	defer func() {
 		// Always reconcile the Status.Phase field.
@@ -119,9 +123,23 @@ func (r *ClusterManagerReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			// if err := patchClusterManager(context.TODO(), patchHelper, clusterManager, patchOpts...); err != nil {
 			// reterr = kerrors.NewAggregate([]error{reterr, err})
 			reterr = err
+		} else {
+			// patch에 실패하면 다음 reconcile에서 다시 완료되므로 patch된 경우에만 기록한다.
+			observePhaseDurations(before, clusterManager, time.Now())
+			r.recordTransitionEvents(before, clusterManager)
 		}
 	}()
 
+	// paused인 경우 capi cluster에 전파하고, 삭제를 포함한 다른 작업은 수행하지 않는다.
+	if err := r.reconcilePause(context.TODO(), clusterManager); err != nil {
+		log.Error(err, "Failed to propagate paused to Cluster")
+		return ctrl.Result{}, err
+	}
+	if clusterManager.Spec.Paused {
+		log.Info("ClusterManager is paused. Skip reconciliation until spec.paused is false")
+		return ctrl.Result{}, nil
+	}
+
 	// Add finalizer first if not exist to avoid the race condition between init and delete
 	if !controllerutil.ContainsFinalizer(clusterManager, clusterV1alpha1.ClusterManagerFinalizer) {
 		controllerutil.AddFinalizer(clusterManager, clusterV1alpha1.ClusterManagerFinalizer)
@@ -131,6 +149,7 @@ func (r *ClusterManagerReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	// Handle deletion reconciliation loop.
 	if !clusterManager.ObjectMeta.DeletionTimestamp.IsZero() {
 		clusterManager.Status.Ready = false
+		clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeReady, clusterV1alpha1.ConditionReasonDeleting, "")
 		return r.reconcileDelete(context.TODO(), clusterManager)
 	}
 
@@ -138,19 +157,71 @@ func (r *ClusterManagerReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	return r.reconcile(context.TODO(), clusterManager)
 }
 
+// reconcilePause는 cluster manager의 spec.paused를 condition에 기록하고, capi cluster의 spec.paused에 전파한다.
+// capi cluster가 아직 생성되지 않은 경우에는 전파하지 않는다.
+func (r *ClusterManagerReconciler) reconcilePause(ctx context.Context, clusterManager *clusterV1alpha1.ClusterManager) error {
+	if clusterManager.Spec.Paused {
+		clusterManager.MarkConditionTrue(clusterV1alpha1.ConditionTypePaused, clusterV1alpha1.ConditionReasonPausedBySpec, "")
+	} else if clusterManager.IsConditionTrue(clusterV1alpha1.ConditionTypePaused) {
+		clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypePaused, clusterV1alpha1.ConditionReasonResumed, "")
+	}
+
+	if clusterManager.GetClusterType() != clusterV1alpha1.ClusterTypeCreated {
+		return nil
+	}
+
+	cluster := &capiV1alpha3.Cluster{}
+	if err := r.Client.Get(ctx, clusterManager.GetNamespacedName(), cluster); errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if cluster.Spec.Paused == clusterManager.Spec.Paused {
+		return nil
+	}
+
+	before := cluster.DeepCopy()
+	cluster.Spec.Paused = clusterManager.Spec.Paused
+	return r.Patch(ctx, cluster, client.MergeFrom(before))
+}
+
 // reconcile handles cluster reconciliation.
 func (r *ClusterManagerReconciler) reconcile(ctx context.Context, clusterManager *clusterV1alpha1.ClusterManager) (ctrl.Result, error) {
+	log := r.Log.WithValues("clustermanager", clusterManager.GetNamespacedName())
+
+	// retry annotation이 있으면 failure를 지우고 제한 시간을 다시 계산한다.
+	if _, ok := clusterManager.Annotations[clusterV1alpha1.AnnotationKeyClmRetry]; ok {
+		log.Info("Retry annotation is found. Clear failure and retry")
+		delete(clusterManager.Annotations, clusterV1alpha1.AnnotationKeyClmRetry)
+		clusterManager.Status.ClearFailure()
+		now := metav1.Now()
+		clusterManager.Status.LastRetryTime = &now
+	}
+
+	if clusterManager.Status.IsFailed() {
+		log.Info("ClusterManager is failed. Add retry annotation to retry",
+			"reason", clusterManager.Status.FailureReason, "annotation", clusterV1alpha1.AnnotationKeyClmRetry)
+		return ctrl.Result{}, nil
+	}
 
 	type phaseFunc func(context.Context, *clusterV1alpha1.ClusterManager) (ctrl.Result, error)
 	phases := []phaseFunc{}
 	phases = append(phases, r.ReadyReconcilePhase)
 
+	res, deferred := ctrl.Result{}, false
 	if clusterManager.GetClusterType() == clusterV1alpha1.ClusterTypeCreated {
+		// maintenance window 밖이면 upgrade, scaling, worker pool 변경을 시작하지 않고 일반 phase만 수행하며 다음 window까지 기다린다.
+		res, deferred = r.deferToMaintenanceWindow(clusterManager)
+
 		// cluster claim 으로 cluster 를 생성한 경우에만 수행
+		// cluster manager 의  metadata 와 provider 정보를 template instance 의 parameter 값에 넣어 template instance 를 생성한다.
+		phases = append(phases, r.CreateTemplateInstance)
+		if !deferred {
+			// 추가/삭제된 worker pool에 대한 machinedeployment template instance를 생성/삭제한다.
+			phases = append(phases, r.ReconcileWorkerPools)
+		}
 		phases = append(
 			phases,
-			// cluster manager 의  metadata 와 provider 정보를 template instance 의 parameter 값에 넣어 template instance 를 생성한다.
-			r.CreateTemplateInstance,
 			// cluster manager 가 바라봐야 할 cluster 의 endpoint 를 annotation 으로 달아준다.
 			r.SetEndpoint,
 			// scaling을 roll back하는 경우, kcp와 md의 replicas를 원래대로 돌려놓는다.
@@ -187,21 +258,19 @@ func (r *ClusterManagerReconciler) reconcile(ctx context.Context, clusterManager
 	)
 
 	// special case- capi upgrade/master scaling/worker scaling
-	if clusterManager.GetClusterType() == clusterV1alpha1.ClusterTypeCreated {
+	if clusterManager.GetClusterType() == clusterV1alpha1.ClusterTypeCreated && !deferred {
 		if clusterManager.Status.GetK8SVersion() != "" && clusterManager.GetK8SVersion() != clusterManager.Status.GetK8SVersion() {
-			phases = []phaseFunc{}
-			if clusterManager.Spec.Provider == clusterV1alpha1.ProviderVSphere {
-				phases = append(phases, r.CreateUpgradeTemplateInstance)
+			phases = []phaseFunc{
+				r.CreateUpgradeTemplateInstance,
+				r.UpgradeCluster,
 			}
-			phases = append(phases, r.UpgradeCluster)
 		} else if clusterManager.Status.MasterNum != 0 && clusterManager.Spec.MasterNum != clusterManager.Status.MasterNum {
 			phases = []phaseFunc{r.ScaleControlplane}
-		} else if clusterManager.Status.WorkerNum != 0 && clusterManager.Spec.WorkerNum != clusterManager.Status.WorkerNum {
+		} else if clusterManager.IsWorkerScaling() {
 			phases = []phaseFunc{r.ScaleWorker}
 		}
 	}
 
-	res := ctrl.Result{}
 	errs := []error{}
 	// phases 를 돌면서, append 한 함수들을 순차적으로 수행하고,
 	// error가 있는지 체크하여 error가 있으면 무조건 requeue
@@ -213,6 +282,7 @@ func (r *ClusterManagerReconciler) reconcile(ctx context.Context, clusterManager
 		// Call the inner reconciliation methods.
 		phaseResult, err := phase(ctx, clusterManager)
 		if err != nil {
+			metrics.PhaseErrors.WithLabelValues("clustermanager", metrics.FuncName(phase)).Inc()
 			errs = append(errs, err)
 		}
 		if len(errs) > 0 {
@@ -223,6 +293,20 @@ func (r *ClusterManagerReconciler) reconcile(ctx context.Context, clusterManager
 		res = util.LowestNonZeroResult(res, phaseResult)
 	}
 
+	// 재시도할 수 없는 에러가 발생했거나 단계별 제한 시간을 넘긴 경우 Failed 상태로 변경
+	for _, err := range errs {
+		if reason := classifyError(err); reason != "" {
+			log.Error(err, "Non-retryable error occurred. ClusterManager is failed", "reason", reason)
+			clusterManager.Status.SetFailure(reason, err.Error())
+			return ctrl.Result{}, nil
+		}
+	}
+	if reason, message := checkDeadline(clusterManager, time.Now()); reason != "" {
+		log.Info("Deadline exceeded. ClusterManager is failed", "reason", reason, "message", message)
+		clusterManager.Status.SetFailure(reason, message)
+		return ctrl.Result{}, nil
+	}
+
 	return res, kerrors.NewAggregate(errs)
 }
 
@@ -233,18 +317,24 @@ func (r *ClusterManagerReconciler) reconcileDelete(ctx context.Context, clusterM
 	ARGO_APP_DELETE := os.Getenv(util.ARGO_APP_DELETE)
 	if util.IsTrue(ARGO_APP_DELETE) {
 		if err := r.DeleteApplicationRemains(clusterManager); err != nil {
+			r.Recorder.Eventf(clusterManager, coreV1.EventTypeNormal, "WaitingForApplications", "Deleting ArgoCD applications: %v", err)
 			return ctrl.Result{RequeueAfter: requeueAfter10Second}, nil
 		}
 	} else {
 		if err := r.CheckApplicationRemains(clusterManager); err != nil {
+			r.Recorder.Event(clusterManager, coreV1.EventTypeWarning, "WaitingForApplications", err.Error())
 			return ctrl.Result{RequeueAfter: requeueAfter10Second}, nil
 		}
 	}
 
 	// ClusterAPI-provider-aws의 경우, lb type의 svc가 남아있으면 infra nlb deletion이 stuck걸리면서 클러스터가 지워지지 않는 버그가 있음
 	// 이를 해결하기 위해 클러스터를 삭제하기 전에 lb type의 svc를 전체 삭제한 후 클러스터를 삭제
-	if err := r.DeleteLoadBalancerServices(clusterManager); err != nil {
-		return ctrl.Result{}, err
+	// detach하는 경우에는 cluster의 workload를 유지해야 하므로 삭제하지 않는다.
+	if clusterManager.GetDeletionPolicy() != clusterV1alpha1.ClusterDeletionPolicyDetach {
+		if err := r.DeleteLoadBalancerServices(clusterManager); err != nil {
+			r.Recorder.Eventf(clusterManager, coreV1.EventTypeWarning, "DeletionFailed", "Failed to delete LoadBalancer services: %v", err)
+			return ctrl.Result{}, err
+		}
 	}
 
 	if err := r.DeleteIngressRoute(clusterManager); err != nil {
@@ -252,10 +342,16 @@ func (r *ClusterManagerReconciler) reconcileDelete(ctx context.Context, clusterM
 	}
 
 	if err := r.DeleteHyperAuthResources(clusterManager); err != nil {
+		r.Recorder.Eventf(clusterManager, coreV1.EventTypeWarning, "DeletionFailed", "Failed to delete HyperAuth clients and groups: %v", err)
 		return ctrl.Result{}, err
 	}
 
-	// cluster type label을 지우면 생성 타입 클러스터를 지우지 않고 분리할 수 있음
+	// deletion policy가 Detach이면 생성 타입 클러스터를 지우지 않고 분리한다.
+	if clusterManager.GetClusterType() == clusterV1alpha1.ClusterTypeCreated &&
+		clusterManager.GetDeletionPolicy() == clusterV1alpha1.ClusterDeletionPolicyDetach {
+		return r.reconcileDetach(ctx, clusterManager)
+	}
+
 	if clusterManager.GetClusterType() == clusterV1alpha1.ClusterTypeCreated {
 		// delete templateinstance
 		key := types.NamespacedName{
@@ -274,6 +370,7 @@ func (r *ClusterManagerReconciler) reconcileDelete(ctx context.Context, clusterM
 				log.Error(err, "Failed to delete templateinstance")
 				return ctrl.Result{}, err
 			}
+			r.Recorder.Event(clusterManager, coreV1.EventTypeNormal, "DeletingCluster", "TemplateInstance is deleted. Waiting for cluster and infrastructure to be deleted")
 		}
 	}
 
@@ -305,6 +402,7 @@ func (r *ClusterManagerReconciler) reconcileDelete(ctx context.Context, clusterM
 	if errors.IsNotFound(err) {
 		if err := util.Delete(clusterManager.Namespace, clusterManager.Name); err != nil {
 			log.Error(err, "Failed to delete cluster info from cluster_member table")
+			r.Recorder.Eventf(clusterManager, coreV1.EventTypeWarning, "DeletionFailed", "Failed to delete cluster member info: %v", err)
 			return ctrl.Result{}, err
 		}
 		// kubeconfig secret이 없다면(모든 시크릿이 삭제되었다면) clm을 삭제한다.
@@ -315,6 +413,7 @@ func (r *ClusterManagerReconciler) reconcileDelete(ctx context.Context, clusterM
 		if err := r.Client.Get(context.TODO(), key, &coreV1.Secret{}); errors.IsNotFound(err) {
 			controllerutil.RemoveFinalizer(clusterManager, clusterV1alpha1.ClusterManagerFinalizer)
 			log.Info("Cluster manager was deleted successfully")
+			r.Recorder.Event(clusterManager, coreV1.EventTypeNormal, "Deleted", "Cluster is deleted")
 			// 끝
 			return ctrl.Result{}, nil
 		} else if err != nil {
@@ -351,7 +450,13 @@ func (r *ClusterManagerReconciler) reconcilePhase(_ context.Context, clusterMana
 		return
 	}
 
-	if clusterManager.Status.GetTypedPhase() == "" {
+	if clusterManager.Status.IsFailed() {
+		clusterManager.Status.SetTypedPhase(clusterV1alpha1.ClusterManagerPhaseFailed)
+		return
+	}
+
+	if clusterManager.Status.GetTypedPhase() == "" ||
+		clusterManager.Status.GetTypedPhase() == clusterV1alpha1.ClusterManagerPhaseFailed {
 		clusterManager.Status.SetTypedPhase(clusterV1alpha1.ClusterManagerPhaseProcessing)
 	}
 
@@ -367,9 +472,14 @@ func (r *ClusterManagerReconciler) reconcilePhase(_ context.Context, clusterMana
 		clusterManager.Status.SetTypedPhase(clusterV1alpha1.ClusterManagerPhaseReady)
 	}
 
+	// maintenance window를 기다리는 변경 사항은 scaling, upgrading으로 표시하지 않는다.
+	if clusterManager.IsConditionTrue(clusterV1alpha1.ConditionTypeMaintenancePending) {
+		return
+	}
+
 	// cluster scaling
 	if (clusterManager.Status.MasterNum != 0 && clusterManager.Spec.MasterNum != clusterManager.Status.MasterNum) ||
-		(clusterManager.Status.WorkerNum != 0 && clusterManager.Spec.WorkerNum != clusterManager.Status.WorkerNum) {
+		clusterManager.IsWorkerScaling() {
 		clusterManager.Status.SetTypedPhase(clusterV1alpha1.ClusterManagerPhaseScaling)
 		return
 	}
@@ -395,6 +505,11 @@ func (r *ClusterManagerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 					oldclm := e.ObjectOld.(*clusterV1alpha1.ClusterManager)
 					newclm := e.ObjectNew.(*clusterV1alpha1.ClusterManager)
 
+					// status collector, health prober가 기록한 값만 바뀐 경우는 reconcile할 필요가 없다.
+					if isPeriodicStatusUpdate(oldclm, newclm) {
+						return false
+					}
+
 					isFinalized := !controllerutil.ContainsFinalizer(oldclm, clusterV1alpha1.ClusterManagerFinalizer) &&
 						controllerutil.ContainsFinalizer(newclm, clusterV1alpha1.ClusterManagerFinalizer)
 					isDelete := oldclm.DeletionTimestamp.IsZero() &&
@@ -404,8 +519,11 @@ func (r *ClusterManagerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 					isSubResourceNotReady := !newclm.Status.ArgoReady || !newclm.Status.TraefikReady || !newclm.Status.GatewayReady
 					isUpgrade := oldclm.GetK8SVersion() != "" && oldclm.GetK8SVersion() != newclm.GetK8SVersion()
 					isScaling := oldclm.Spec.MasterNum != newclm.Spec.MasterNum ||
-						oldclm.Spec.WorkerNum != newclm.Spec.WorkerNum
-					if isDelete || isControlPlaneEndpointUpdate || isFinalized || isUpgrade || isScaling {
+						oldclm.Spec.WorkerNum != newclm.Spec.WorkerNum ||
+						!reflect.DeepEqual(oldclm.Spec.WorkerPools, newclm.Spec.WorkerPools)
+					_, isRetry := newclm.Annotations[clusterV1alpha1.AnnotationKeyClmRetry]
+					isPauseChanged := oldclm.Spec.Paused != newclm.Spec.Paused
+					if isDelete || isControlPlaneEndpointUpdate || isFinalized || isUpgrade || isScaling || isRetry || isPauseChanged {
 						return true
 					} else {
 						if newclm.GetClusterType() == clusterV1alpha1.ClusterTypeCreated {
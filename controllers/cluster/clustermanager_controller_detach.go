@@ -0,0 +1,189 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/provider"
+	util "github.com/tmax-cloud/hypercloud-multi-operator/controllers/util"
+	tmaxv1 "github.com/tmax-cloud/template-operator/api/v1"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// reconcileDetach는 생성 타입 클러스터를 삭제하지 않고 cluster manager에서 분리한다.
+// HyperAuth client, traefik 리소스는 reconcileDelete에서 삭제하고, 여기서는 argocd secret을 삭제한다.
+// template instance, machine template은 cluster manager와 함께 삭제되지 않도록 owner reference를 제거하므로
+// capi cluster와 infra는 계속 동작한다.
+func (r *ClusterManagerReconciler) reconcileDetach(ctx context.Context, clusterManager *clusterV1alpha1.ClusterManager) (ctrl.Result, error) {
+	log := r.Log.WithValues("clustermanager", clusterManager.GetNamespacedName())
+	log.Info("Start to reconcile phase for detach")
+
+	if err := r.orphanClusterResources(ctx, clusterManager); err != nil {
+		log.Error(err, "Failed to remove owner reference from cluster resources")
+		return ctrl.Result{}, err
+	}
+
+	// argocd cluster secret, service account token secret은 secret controller가 finalizer를 제거한 뒤 삭제된다.
+	remains, err := r.deleteDetachedClusterSecrets(ctx, clusterManager)
+	if err != nil {
+		log.Error(err, "Failed to delete secrets for detached cluster")
+		return ctrl.Result{}, err
+	}
+	if remains {
+		log.Info("Waiting for secrets of cluster manager to be deleted")
+		return ctrl.Result{RequeueAfter: requeueAfter10Second}, nil
+	}
+
+	// kubeconfig secret은 capi가 관리하므로 남겨두고, secret controller가 더 이상 처리하지 않도록 표시한다.
+	kubeconfigSecret, err := r.GetKubeconfigSecret(clusterManager)
+	if err != nil && !errors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	} else if err == nil {
+		before := kubeconfigSecret.DeepCopy()
+		if kubeconfigSecret.Annotations == nil {
+			kubeconfigSecret.Annotations = map[string]string{}
+		}
+		kubeconfigSecret.Annotations[clusterV1alpha1.AnnotationKeyClmDetached] = "true"
+		controllerutil.RemoveFinalizer(kubeconfigSecret, clusterV1alpha1.ClusterManagerFinalizer)
+		if err := r.Patch(ctx, kubeconfigSecret, client.MergeFrom(before)); err != nil {
+			log.Error(err, "Failed to patch kubeconfig secret")
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := util.Delete(clusterManager.Namespace, clusterManager.Name); err != nil {
+		log.Error(err, "Failed to delete cluster info from cluster_member table")
+		return ctrl.Result{}, err
+	}
+
+	controllerutil.RemoveFinalizer(clusterManager, clusterV1alpha1.ClusterManagerFinalizer)
+	log.Info("Cluster is detached from cluster manager successfully")
+	r.Recorder.Event(clusterManager, coreV1.EventTypeNormal, "Detached", "Cluster is detached. CAPI Cluster and infrastructure are kept")
+	return ctrl.Result{}, nil
+}
+
+// orphanClusterResources는 cluster를 구성하는 template instance와 provider가 직접 생성한 machine template의
+// owner reference에서 cluster manager를 제거한다.
+func (r *ClusterManagerReconciler) orphanClusterResources(ctx context.Context, clusterManager *clusterV1alpha1.ClusterManager) error {
+	tiList := &tmaxv1.TemplateInstanceList{}
+	if err := r.List(ctx, tiList, client.InNamespace(clusterManager.Namespace)); err != nil {
+		return err
+	}
+	for i := range tiList.Items {
+		if err := r.removeOwnerReference(ctx, &tiList.Items[i], clusterManager); err != nil {
+			return err
+		}
+	}
+
+	p, err := provider.Get(clusterManager.Spec.Provider)
+	if err != nil {
+		return err
+	}
+	machineTemplates := []*unstructured.Unstructured{}
+	if mtp, ok := p.(provider.MachineTemplateProvider); ok {
+		machineTemplates = append(machineTemplates, mtp.ControlPlaneMachineTemplate(clusterManager))
+		for _, pool := range clusterManager.GetWorkerPools() {
+			machineTemplates = append(machineTemplates, mtp.WorkerMachineTemplate(clusterManager, pool))
+		}
+	}
+	for _, upgradeTemplate := range p.PrepareUpgrade(clusterManager).Templates() {
+		if upgradeTemplate.Object != nil {
+			machineTemplates = append(machineTemplates, upgradeTemplate.Object)
+		}
+	}
+
+	for _, machineTemplate := range machineTemplates {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(machineTemplate.GroupVersionKind())
+		key := types.NamespacedName{Name: machineTemplate.GetName(), Namespace: clusterManager.Namespace}
+		if err := r.Get(ctx, key, obj); errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if err := r.removeOwnerReference(ctx, obj, clusterManager); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ClusterManagerReconciler) removeOwnerReference(ctx context.Context, obj client.Object, clusterManager *clusterV1alpha1.ClusterManager) error {
+	refs := []metav1.OwnerReference{}
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID != clusterManager.UID {
+			refs = append(refs, ref)
+		}
+	}
+	if len(refs) == len(obj.GetOwnerReferences()) {
+		return nil
+	}
+
+	before := obj.DeepCopyObject().(client.Object)
+	obj.SetOwnerReferences(refs)
+	return r.Patch(ctx, obj, client.MergeFrom(before))
+}
+
+// deleteDetachedClusterSecrets는 argocd cluster secret과 service account token secret을 삭제하고,
+// 아직 삭제되지 않은 secret이 남아있는지 반환한다.
+func (r *ClusterManagerReconciler) deleteDetachedClusterSecrets(ctx context.Context, clusterManager *clusterV1alpha1.ClusterManager) (bool, error) {
+	secrets := []coreV1.Secret{}
+
+	kubeconfigSecret, err := r.GetKubeconfigSecret(clusterManager)
+	if err != nil && !errors.IsNotFound(err) {
+		return false, err
+	} else if err == nil && kubeconfigSecret.Annotations[util.AnnotationKeyArgoClusterSecret] != "" {
+		key := types.NamespacedName{
+			Name:      kubeconfigSecret.Annotations[util.AnnotationKeyArgoClusterSecret],
+			Namespace: util.ArgoNamespace,
+		}
+		argoClusterSecret := &coreV1.Secret{}
+		if err := r.Get(ctx, key, argoClusterSecret); err == nil {
+			secrets = append(secrets, *argoClusterSecret)
+		} else if !errors.IsNotFound(err) {
+			return false, err
+		}
+	}
+
+	saTokenSecretList := &coreV1.SecretList{}
+	if err := r.List(ctx, saTokenSecretList, client.InNamespace(clusterManager.Namespace), client.MatchingLabels{
+		util.LabelKeyClmSecretType:      util.ClmSecretTypeSAToken,
+		clusterV1alpha1.LabelKeyClmName: clusterManager.Name,
+	}); err != nil {
+		return false, err
+	}
+	secrets = append(secrets, saTokenSecretList.Items...)
+
+	for i := range secrets {
+		if !secrets[i].DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.Delete(ctx, &secrets[i]); err != nil && !errors.IsNotFound(err) {
+			return false, err
+		}
+	}
+	return len(secrets) > 0, nil
+}
@@ -0,0 +1,74 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+
+	coreV1 "k8s.io/api/core/v1"
+)
+
+// warning event로 기록하는 condition reason
+var warningConditionReasons = map[string]bool{
+	clusterV1alpha1.ConditionReasonKubeconfigNotFound:       true,
+	clusterV1alpha1.ConditionReasonAuthClientFailed:         true,
+	clusterV1alpha1.ConditionReasonTraefikFailed:            true,
+	clusterV1alpha1.ConditionReasonSubresourceLost:          true,
+	clusterV1alpha1.ConditionReasonClusterUnhealthy:         true,
+	clusterV1alpha1.ConditionReasonClusterNotResponding:     true,
+	clusterV1alpha1.ConditionReasonPreflightChecksFailed:    true,
+	clusterV1alpha1.ConditionReasonInvalidMaintenanceWindow: true,
+}
+
+// recordTransitionEvents는 reconcile 전후의 phase와 condition을 비교하여 변경된 내용을 event로 남긴다.
+// condition은 status 또는 reason이 바뀐 경우에만 기록하므로, scaling 진행률과 같은 message 변경은 기록하지 않는다.
+func (r *ClusterManagerReconciler) recordTransitionEvents(before, after *clusterV1alpha1.ClusterManager) {
+	for _, cond := range after.Status.Conditions {
+		// 기존 status를 옮긴 condition은 새로 발생한 변경이 아니다.
+		if cond.Reason == clusterV1alpha1.ConditionReasonMigrated {
+			continue
+		}
+		if prev := before.GetCondition(cond.Type); prev != nil && prev.Status == cond.Status && prev.Reason == cond.Reason {
+			continue
+		}
+
+		eventType := coreV1.EventTypeNormal
+		if warningConditionReasons[cond.Reason] {
+			eventType = coreV1.EventTypeWarning
+		}
+		message := fmt.Sprintf("%s is %s", cond.Type, cond.Status)
+		if cond.Message != "" {
+			message += ": " + cond.Message
+		}
+		r.Recorder.Event(after, eventType, cond.Reason, message)
+	}
+
+	oldPhase, newPhase := before.Status.GetTypedPhase(), after.Status.GetTypedPhase()
+	if oldPhase == newPhase || newPhase == "" {
+		return
+	}
+	if newPhase == clusterV1alpha1.ClusterManagerPhaseFailed {
+		r.Recorder.Eventf(after, coreV1.EventTypeWarning, "Failed",
+			"Phase changed from %s to %s (%s): %s", oldPhase, newPhase, after.Status.FailureReason, after.Status.FailureMessage)
+		return
+	}
+	if oldPhase == "" {
+		r.Recorder.Eventf(after, coreV1.EventTypeNormal, "PhaseChanged", "Phase changed to %s", newPhase)
+		return
+	}
+	r.Recorder.Eventf(after, coreV1.EventTypeNormal, "PhaseChanged", "Phase changed from %s to %s", oldPhase, newPhase)
+}
@@ -0,0 +1,149 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// phaseDeadline은 condition이 true가 되기까지 기다릴 수 있는 최대 시간
+// 이전 단계의 condition이 true가 된 시점부터 계산한다.
+type phaseDeadline struct {
+	conditionType string
+	timeout       time.Duration
+	reason        clusterV1alpha1.ClusterManagerFailureReason
+}
+
+// 생성/등록 단계는 순서대로 진행되므로 slice의 순서를 유지해야 한다.
+var provisioningDeadlines = []phaseDeadline{
+	{clusterV1alpha1.ConditionTypeInfrastructureReady, 40 * time.Minute, clusterV1alpha1.FailureReasonInfrastructureTimeout},
+	{clusterV1alpha1.ConditionTypeControlPlaneReady, 30 * time.Minute, clusterV1alpha1.FailureReasonControlPlaneTimeout},
+	{clusterV1alpha1.ConditionTypeArgoReady, 20 * time.Minute, clusterV1alpha1.FailureReasonArgoTimeout},
+	{clusterV1alpha1.ConditionTypeGatewayReady, 30 * time.Minute, clusterV1alpha1.FailureReasonGatewayTimeout},
+	{clusterV1alpha1.ConditionTypeAuthClientReady, 10 * time.Minute, clusterV1alpha1.FailureReasonAuthClientTimeout},
+	{clusterV1alpha1.ConditionTypeTraefikReady, 10 * time.Minute, clusterV1alpha1.FailureReasonTraefikTimeout},
+}
+
+const (
+	upgradeDeadline = 1 * time.Hour
+	scalingDeadline = 40 * time.Minute
+)
+
+// terminalError는 재시도해도 해결되지 않는 에러를 나타낸다.
+// phase에서 terminalError를 반환하면 cluster manager는 Failed 상태가 된다.
+type terminalError struct {
+	reason clusterV1alpha1.ClusterManagerFailureReason
+	err    error
+}
+
+func (e *terminalError) Error() string {
+	return e.err.Error()
+}
+
+func (e *terminalError) Unwrap() error {
+	return e.err
+}
+
+func newTerminalError(reason clusterV1alpha1.ClusterManagerFailureReason, err error) error {
+	return &terminalError{reason: reason, err: err}
+}
+
+// classifyError는 phase에서 발생한 에러가 재시도 불가능한 에러인지 판단하여 failure reason을 반환한다.
+// 재시도 가능한 에러인 경우 빈 문자열을 반환한다.
+func classifyError(err error) clusterV1alpha1.ClusterManagerFailureReason {
+	if t, ok := err.(*terminalError); ok {
+		return t.reason
+	}
+	// api server가 요청 자체를 거부한 경우, 동일한 요청을 반복해도 결과가 같다.
+	if errors.IsInvalid(err) || errors.IsBadRequest(err) {
+		return clusterV1alpha1.FailureReasonInvalidConfiguration
+	}
+	return ""
+}
+
+// checkDeadline은 진행중인 단계가 제한 시간을 넘겼는지 확인한다.
+// 제한 시간은 cluster manager의 생성 시점이 아니라 단계가 시작된 시점부터 계산한다.
+// 제한 시간을 넘긴 경우 failure reason과 message를 반환한다.
+func checkDeadline(clusterManager *clusterV1alpha1.ClusterManager, now time.Time) (clusterV1alpha1.ClusterManagerFailureReason, string) {
+	since := func(times ...metav1.Time) time.Time {
+		start := time.Time{}
+		if clusterManager.Status.LastRetryTime != nil {
+			start = clusterManager.Status.LastRetryTime.Time
+		}
+		for _, t := range times {
+			if t.After(start) {
+				start = t.Time
+			}
+		}
+		return start
+	}
+
+	for _, c := range []struct {
+		conditionType string
+		timeout       time.Duration
+		reason        clusterV1alpha1.ClusterManagerFailureReason
+	}{
+		{clusterV1alpha1.ConditionTypeUpgrading, upgradeDeadline, clusterV1alpha1.FailureReasonUpgradeTimeout},
+		{clusterV1alpha1.ConditionTypeScaling, scalingDeadline, clusterV1alpha1.FailureReasonScalingTimeout},
+	} {
+		if cond := clusterManager.GetCondition(c.conditionType); cond != nil && cond.Status == metav1.ConditionTrue {
+			if start := since(cond.LastTransitionTime); now.Sub(start) > c.timeout {
+				return c.reason, fmt.Sprintf("%s did not complete within %s: %s", c.conditionType, c.timeout, cond.Message)
+			}
+			return "", ""
+		}
+	}
+
+	prev := metav1.Time{}
+	for _, d := range provisioningDeadlines {
+		// 등록한 클러스터는 infra 생성 단계가 없다.
+		if d.conditionType == clusterV1alpha1.ConditionTypeInfrastructureReady &&
+			clusterManager.GetClusterType() != clusterV1alpha1.ClusterTypeCreated {
+			continue
+		}
+
+		cond := clusterManager.GetCondition(d.conditionType)
+		if cond != nil && cond.Status == metav1.ConditionTrue {
+			prev = cond.LastTransitionTime
+			continue
+		}
+
+		// condition이 없는 단계는 지금 시작한 것으로 기록한다.
+		// 오래전에 준비된 cluster에서 이전 단계의 완료 시점부터 계산하여 바로 실패하지 않도록 한다.
+		if cond == nil {
+			clusterManager.MarkConditionFalse(d.conditionType, clusterV1alpha1.ConditionReasonPhaseStarted, "")
+			cond = clusterManager.GetCondition(d.conditionType)
+		}
+
+		// 한번 준비되었던 리소스가 삭제된 경우에는 condition이 false가 된 시점부터 계산한다.
+		start := since(prev, cond.LastTransitionTime)
+		message := cond.Reason
+		if cond.Message != "" {
+			message += ": " + cond.Message
+		}
+		if now.Sub(start) > d.timeout {
+			return d.reason, fmt.Sprintf("%s is not satisfied within %s. last status: %s", d.conditionType, d.timeout, message)
+		}
+		return "", ""
+	}
+
+	return "", ""
+}
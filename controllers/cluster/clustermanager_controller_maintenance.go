@@ -0,0 +1,113 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// pendingMaintenanceChanges는 spec에 반영되었지만 아직 cluster에 적용되지 않은 upgrade, scaling, worker pool 변경 사항을 반환한다.
+func pendingMaintenanceChanges(clusterManager *clusterV1alpha1.ClusterManager) []string {
+	changes := []string{}
+	if clusterManager.Status.GetK8SVersion() != "" && clusterManager.GetK8SVersion() != clusterManager.Status.GetK8SVersion() {
+		changes = append(changes, fmt.Sprintf("version %s -> %s", clusterManager.Status.GetK8SVersion(), clusterManager.GetK8SVersion()))
+	}
+	if clusterManager.Status.MasterNum != 0 && clusterManager.Spec.MasterNum != clusterManager.Status.MasterNum {
+		changes = append(changes, fmt.Sprintf("masterNum %d -> %d", clusterManager.Status.MasterNum, clusterManager.Spec.MasterNum))
+	}
+	if clusterManager.Status.WorkerNum != 0 && clusterManager.Spec.WorkerNum != clusterManager.Status.WorkerNum {
+		changes = append(changes, fmt.Sprintf("workerNum %d -> %d", clusterManager.Status.WorkerNum, clusterManager.Spec.WorkerNum))
+	}
+	// 기본 worker pool의 status가 없으면 worker pool을 아직 생성하지 않은 cluster이다.
+	if clusterManager.Status.GetWorkerPoolStatus(clusterV1alpha1.DefaultWorkerPoolName) == nil {
+		return changes
+	}
+	for _, pool := range clusterManager.Spec.WorkerPools {
+		status := clusterManager.Status.GetWorkerPoolStatus(pool.Name)
+		switch {
+		case status == nil:
+			changes = append(changes, fmt.Sprintf("workerPool [%s] added", pool.Name))
+		case status.TemplateHash != "" && status.TemplateHash != pool.TemplateHash():
+			changes = append(changes, fmt.Sprintf("workerPool [%s] template changed", pool.Name))
+		case status.Replicas != pool.Replicas:
+			changes = append(changes, fmt.Sprintf("workerPool [%s] replicas %d -> %d", pool.Name, status.Replicas, pool.Replicas))
+		}
+	}
+	for _, status := range clusterManager.Status.WorkerPools {
+		if status.Name != clusterV1alpha1.DefaultWorkerPoolName && clusterManager.GetWorkerPool(status.Name) == nil {
+			changes = append(changes, fmt.Sprintf("workerPool [%s] removed", status.Name))
+		}
+	}
+	return changes
+}
+
+// deferToMaintenanceWindow는 maintenance window 밖에서 upgrade, scaling이 필요한 경우
+// MaintenancePending condition에 변경 사항을 기록하고 다음 window까지 기다리도록 한다.
+// 이미 시작한 upgrade, scaling은 window가 끝나더라도 계속 진행한다.
+func (r *ClusterManagerReconciler) deferToMaintenanceWindow(clusterManager *clusterV1alpha1.ClusterManager) (ctrl.Result, bool) {
+	log := r.Log.WithValues("clustermanager", clusterManager.GetNamespacedName())
+
+	changes := pendingMaintenanceChanges(clusterManager)
+	window := clusterManager.Spec.MaintenanceWindow
+	inProgress := clusterManager.IsConditionTrue(clusterV1alpha1.ConditionTypeUpgrading) ||
+		clusterManager.IsConditionTrue(clusterV1alpha1.ConditionTypeScaling)
+	if len(changes) == 0 || window == nil || inProgress {
+		clearMaintenancePending(clusterManager, len(changes) == 0)
+		return ctrl.Result{}, false
+	}
+
+	now := time.Now()
+	open, next, err := window.Next(now)
+	if err != nil {
+		// 잘못된 window로 인해 의도하지 않은 시간에 변경되지 않도록 window가 수정될 때까지 기다린다.
+		log.Error(err, "Invalid maintenance window. Changes are pending until the window is fixed")
+		clusterManager.MarkConditionTrue(
+			clusterV1alpha1.ConditionTypeMaintenancePending,
+			clusterV1alpha1.ConditionReasonInvalidMaintenanceWindow,
+			fmt.Sprintf("%s: %s", strings.Join(changes, ", "), err.Error()),
+		)
+		return ctrl.Result{}, true
+	}
+	if open {
+		log.Info("Maintenance window is open. Apply pending changes", "changes", changes, "windowEnd", next)
+		clearMaintenancePending(clusterManager, false)
+		return ctrl.Result{}, false
+	}
+
+	log.Info("Changes are pending until the next maintenance window", "changes", changes, "nextWindow", next)
+	clusterManager.MarkConditionTrue(
+		clusterV1alpha1.ConditionTypeMaintenancePending,
+		clusterV1alpha1.ConditionReasonWaitingForMaintenanceWindow,
+		fmt.Sprintf("%s will be applied at %s", strings.Join(changes, ", "), next.UTC().Format(time.RFC3339)),
+	)
+	return ctrl.Result{RequeueAfter: next.Sub(now)}, true
+}
+
+func clearMaintenancePending(clusterManager *clusterV1alpha1.ClusterManager, noChanges bool) {
+	if !clusterManager.IsConditionTrue(clusterV1alpha1.ConditionTypeMaintenancePending) {
+		return
+	}
+	reason := clusterV1alpha1.ConditionReasonMaintenanceStarted
+	if noChanges {
+		reason = clusterV1alpha1.ConditionReasonNoPendingChanges
+	}
+	clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeMaintenancePending, reason, "")
+}
@@ -0,0 +1,97 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+)
+
+func TestPendingMaintenanceChanges(t *testing.T) {
+	gpu := clusterV1alpha1.WorkerPool{Name: "gpu", Replicas: 2, InstanceType: "p3.2xlarge"}
+	applied := func(pool clusterV1alpha1.WorkerPool) clusterV1alpha1.WorkerPoolStatus {
+		return clusterV1alpha1.WorkerPoolStatus{Name: pool.Name, Replicas: pool.Replicas, TemplateHash: pool.TemplateHash()}
+	}
+	defaultPool := clusterV1alpha1.WorkerPoolStatus{Name: clusterV1alpha1.DefaultWorkerPoolName, Replicas: 3}
+
+	tests := []struct {
+		name     string
+		pools    []clusterV1alpha1.WorkerPool
+		statuses []clusterV1alpha1.WorkerPoolStatus
+		want     []string
+	}{
+		{
+			name:     "no changes",
+			pools:    []clusterV1alpha1.WorkerPool{gpu},
+			statuses: []clusterV1alpha1.WorkerPoolStatus{defaultPool, applied(gpu)},
+			want:     []string{},
+		},
+		{
+			name:  "worker pools are not created yet",
+			pools: []clusterV1alpha1.WorkerPool{gpu},
+			want:  []string{},
+		},
+		{
+			name:     "pool added",
+			pools:    []clusterV1alpha1.WorkerPool{gpu},
+			statuses: []clusterV1alpha1.WorkerPoolStatus{defaultPool},
+			want:     []string{"workerPool [gpu] added"},
+		},
+		{
+			name:     "pool removed",
+			statuses: []clusterV1alpha1.WorkerPoolStatus{defaultPool, applied(gpu)},
+			want:     []string{"workerPool [gpu] removed"},
+		},
+		{
+			name:     "pool template changed",
+			pools:    []clusterV1alpha1.WorkerPool{{Name: "gpu", Replicas: 2, InstanceType: "p3.8xlarge"}},
+			statuses: []clusterV1alpha1.WorkerPoolStatus{defaultPool, applied(gpu)},
+			want:     []string{"workerPool [gpu] template changed"},
+		},
+		{
+			name:     "pool replicas changed",
+			pools:    []clusterV1alpha1.WorkerPool{{Name: "gpu", Replicas: 4, InstanceType: "p3.2xlarge"}},
+			statuses: []clusterV1alpha1.WorkerPoolStatus{defaultPool, applied(gpu)},
+			want:     []string{"workerPool [gpu] replicas 2 -> 4"},
+		},
+		{
+			name:     "pool created before template hash",
+			pools:    []clusterV1alpha1.WorkerPool{gpu},
+			statuses: []clusterV1alpha1.WorkerPoolStatus{defaultPool, {Name: "gpu", Replicas: 2}},
+			want:     []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterManager := &clusterV1alpha1.ClusterManager{
+				Spec: clusterV1alpha1.ClusterManagerSpec{
+					MasterNum:   3,
+					WorkerNum:   3,
+					WorkerPools: tt.pools,
+				},
+				Status: clusterV1alpha1.ClusterManagerStatus{
+					MasterNum:   3,
+					WorkerNum:   3,
+					WorkerPools: tt.statuses,
+				},
+			}
+			if got := pendingMaintenanceChanges(clusterManager); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pendingMaintenanceChanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/metrics"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// 생성/등록 단계별 condition과 metric의 phase label
+// 생성/등록 단계는 순서대로 진행되므로 slice의 순서를 유지해야 한다.
+var provisioningMetricPhases = []struct {
+	conditionType string
+	phase         string
+}{
+	// infra 생성부터 control plane 초기화까지
+	{clusterV1alpha1.ConditionTypeControlPlaneReady, "provisioning"},
+	{clusterV1alpha1.ConditionTypeArgoReady, "argocd"},
+	{clusterV1alpha1.ConditionTypeGatewayReady, "gateway"},
+	{clusterV1alpha1.ConditionTypeAuthClientReady, "auth"},
+	{clusterV1alpha1.ConditionTypeTraefikReady, "traefik"},
+}
+
+// observePhaseDurations는 reconcile 전후의 condition을 비교하여 완료된 단계의 소요 시간을 기록한다.
+// 생성/등록 단계는 이전 단계가 완료된 시점부터, upgrade와 scaling은 condition이 true가 된 시점부터 계산한다.
+func observePhaseDurations(before, after *clusterV1alpha1.ClusterManager, now time.Time) {
+	observe := func(phase string, d time.Duration) {
+		metrics.PhaseDuration.WithLabelValues(phase, after.Spec.Provider, after.GetClusterType()).Observe(d.Seconds())
+	}
+
+	start := after.CreationTimestamp.Time
+	if after.Status.LastRetryTime != nil && after.Status.LastRetryTime.After(start) {
+		start = after.Status.LastRetryTime.Time
+	}
+	for _, p := range provisioningMetricPhases {
+		cond := after.GetCondition(p.conditionType)
+		if cond == nil || cond.Status != metav1.ConditionTrue {
+			break
+		}
+
+		// 기존 status를 옮긴 condition은 실제 소요 시간을 알 수 없다.
+		if !before.IsConditionTrue(p.conditionType) && cond.Reason != clusterV1alpha1.ConditionReasonMigrated {
+			phaseStart := start
+			// 한번 준비되었던 리소스가 삭제된 경우에는 condition이 false가 된 시점부터 계산한다.
+			if prev := before.GetCondition(p.conditionType); prev != nil && prev.LastTransitionTime.After(phaseStart) {
+				phaseStart = prev.LastTransitionTime.Time
+			}
+			observe(p.phase, cond.LastTransitionTime.Sub(phaseStart))
+		}
+		if cond.LastTransitionTime.After(start) {
+			start = cond.LastTransitionTime.Time
+		}
+	}
+
+	// 제한 시간을 넘겨 failed가 된 경우는 완료된 것이 아니므로 기록하지 않는다.
+	if after.Status.IsFailed() {
+		return
+	}
+	for conditionType, phase := range map[string]string{
+		clusterV1alpha1.ConditionTypeUpgrading: "upgrade",
+		clusterV1alpha1.ConditionTypeScaling:   "scaling",
+	} {
+		if cond := before.GetCondition(conditionType); cond != nil && cond.Status == metav1.ConditionTrue &&
+			!after.IsConditionTrue(conditionType) {
+			observe(phase, now.Sub(cond.LastTransitionTime.Time))
+		}
+	}
+}
@@ -25,11 +25,13 @@ import (
 	argocdV1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
 	hyperauthCaller "github.com/tmax-cloud/hypercloud-multi-operator/controllers/hyperAuth"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/provider"
 	util "github.com/tmax-cloud/hypercloud-multi-operator/controllers/util"
 	traefikV1alpha1 "github.com/traefik/traefik/v2/pkg/provider/kubernetes/crd/traefik/v1alpha1"
 	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/clientcmd"
@@ -54,6 +56,7 @@ func (r *ClusterManagerReconciler) ReadyReconcilePhase(ctx context.Context, clus
 		clusterManager.Status.GatewayReady = clusterManager.Status.PrometheusReady
 		clusterManager.Status.GatewayReadyMigration = true
 	}
+	clusterManager.MigrateConditions()
 
 	// check Argocd ingress
 	_, err := r.fetchArgocdIngressDomain(clusterManager)
@@ -75,9 +78,17 @@ func (r *ClusterManagerReconciler) ReadyReconcilePhase(ctx context.Context, clus
 	}
 
 	if !clusterManager.Status.TraefikReady {
+		clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeReady, clusterV1alpha1.ConditionReasonWaitingForSubresources, "")
 		return ctrl.Result{RequeueAfter: requeueAfter1Minute}, nil
 	}
+	// health prober가 클러스터에 접근하지 못하는 경우 ready로 바꾸지 않는다.
+	if cond := clusterManager.GetCondition(clusterV1alpha1.ConditionTypeHealthy); cond != nil && cond.Status == metav1.ConditionFalse {
+		clusterManager.Status.Ready = false
+		clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeReady, clusterV1alpha1.ConditionReasonClusterUnhealthy, cond.Message)
+		return ctrl.Result{}, nil
+	}
 	clusterManager.Status.Ready = true
+	clusterManager.MarkConditionTrue(clusterV1alpha1.ConditionTypeReady, clusterV1alpha1.ConditionReasonClusterReady, "")
 	log.Info("ClusterManager is ready successfully")
 
 	return ctrl.Result{}, nil
@@ -93,6 +104,7 @@ func (r *ClusterManagerReconciler) UpdateClusterManagerStatus(ctx context.Contex
 	kubeconfigSecret, err := r.GetKubeconfigSecret(clusterManager)
 	if err != nil {
 		log.Error(err, "Failed to get kubeconfig secret")
+		clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeControlPlaneReady, clusterV1alpha1.ConditionReasonKubeconfigNotFound, err.Error())
 		return ctrl.Result{RequeueAfter: requeueAfter10Second}, nil
 	}
 
@@ -183,13 +195,16 @@ func (r *ClusterManagerReconciler) UpdateClusterManagerStatus(ctx context.Contex
 		DoRaw(context.TODO())
 	if err != nil {
 		log.Error(err, "Failed to get remote cluster status")
+		clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeControlPlaneReady, clusterV1alpha1.ConditionReasonRemoteClusterNotReady, err.Error())
 		return ctrl.Result{}, err
 	}
 	if string(resp) == "ok" {
 		clusterManager.Status.ControlPlaneReady = true
 		clusterManager.Status.Ready = true
+		clusterManager.MarkConditionTrue(clusterV1alpha1.ConditionTypeControlPlaneReady, clusterV1alpha1.ConditionReasonControlPlaneInitialized, "")
 	} else {
 		log.Info("Remote cluster is not ready... wait...")
+		clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeControlPlaneReady, clusterV1alpha1.ConditionReasonRemoteClusterNotReady, "readyz: "+string(resp))
 		return ctrl.Result{RequeueAfter: requeueAfter30Second}, nil
 	}
 
@@ -212,30 +227,42 @@ func (r *ClusterManagerReconciler) CreateTemplateInstance(ctx context.Context, c
 	}
 
 	if err := r.Client.Get(context.TODO(), key, &tmaxv1.TemplateInstance{}); errors.IsNotFound(err) {
-		clusterParams := buildClusterParams(*clusterManager)
-
-		switch strings.ToUpper(clusterManager.Spec.Provider) {
-		case util.ProviderAws:
-			awsParams := buildAwsParams(clusterManager.AwsSpec)
-			clusterParams = mergeParams(clusterParams, awsParams)
-
-		case util.ProviderVsphere:
-			vsphereParams := buildVsphereParams(clusterManager.VsphereSpec)
-			clusterParams = mergeParams(clusterParams, vsphereParams)
+		p, err := provider.Get(clusterManager.Spec.Provider)
+		if err != nil {
+			log.Error(err, "Failed to get provider")
+			return ctrl.Result{}, newTerminalError(clusterV1alpha1.FailureReasonInvalidConfiguration, err)
+		}
+		// template으로 생성할 수 없는 machine template은 template instance보다 먼저 생성한다.
+		if mp, ok := p.(provider.MachineTemplateProvider); ok {
+			defaultPool := clusterV1alpha1.WorkerPool{
+				Name:     clusterV1alpha1.DefaultWorkerPoolName,
+				Replicas: clusterManager.Spec.WorkerNum,
+			}
+			if err := r.createMachineTemplate(clusterManager, mp.ControlPlaneMachineTemplate(clusterManager)); err != nil {
+				return ctrl.Result{}, err
+			}
+			if err := r.createMachineTemplate(clusterManager, mp.WorkerMachineTemplate(clusterManager, defaultPool)); err != nil {
+				return ctrl.Result{}, err
+			}
 		}
 
 		generatedSuffix := util.CreateSuffixString()
 		instanceName := clusterManager.Name + "-" + generatedSuffix
-		templateInstance, err := ConstructTemplateInstance(clusterManager, instanceName, clusterParams, false)
+		templateInstance, err := BuildClusterTemplateInstance(p, clusterManager, instanceName)
 		if err != nil {
 			log.Error(err, "Failed to create TemplateInstance")
-			return ctrl.Result{}, err
+			return ctrl.Result{}, newTerminalError(clusterV1alpha1.FailureReasonInvalidConfiguration, err)
 		}
 		if err = r.Create(context.TODO(), templateInstance); err != nil {
 			log.Error(err, "Failed to create TemplateInstance")
 			return ctrl.Result{}, err
 		}
 		clusterManager.Annotations[clusterV1alpha1.AnnotationKeyClmSuffix] = generatedSuffix
+		clusterManager.MarkConditionFalse(
+			clusterV1alpha1.ConditionTypeInfrastructureReady,
+			clusterV1alpha1.ConditionReasonTemplateInstanceCreated,
+			"TemplateInstance "+instanceName+" is created",
+		)
 	} else if err != nil {
 		log.Error(err, "Failed to get TemplateInstance")
 		return ctrl.Result{}, err
@@ -245,61 +272,193 @@ func (r *ClusterManagerReconciler) CreateTemplateInstance(ctx context.Context, c
 }
 
 // CreateUpgradeTemplateInstance는 클러스터 업그레이드를 위한 템플릿 인스턴스를 생성한다.
+// machine template을 교체할 필요가 없는 provider는 아무것도 하지 않는다.
 func (r *ClusterManagerReconciler) CreateUpgradeTemplateInstance(ctx context.Context, clusterManager *clusterV1alpha1.ClusterManager) (ctrl.Result, error) {
+	p, err := provider.Get(clusterManager.Spec.Provider)
+	if err != nil {
+		return ctrl.Result{}, newTerminalError(clusterV1alpha1.FailureReasonInvalidConfiguration, err)
+	}
+	upgradeTemplates := p.PrepareUpgrade(clusterManager).Templates()
+	if len(upgradeTemplates) == 0 {
+		return ctrl.Result{}, nil
+	}
+
 	log := r.Log.WithValues("clustermanager", clusterManager.GetNamespacedName())
 	log.Info("Start to reconcile phase for CreateUpgradeTemplateInstance")
+	clusterManager.MarkConditionTrue(
+		clusterV1alpha1.ConditionTypeUpgrading,
+		clusterV1alpha1.ConditionReasonWaitingForUpgradeTemplate,
+		"Upgrading to "+clusterManager.GetK8SVersion(),
+	)
+
+	// controlplane, worker pool별 template instance 생성
+	for _, upgradeTemplate := range upgradeTemplates {
+		if err := r.createUpgradeTemplateInstance(clusterManager, upgradeTemplate); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *ClusterManagerReconciler) createUpgradeTemplateInstance(clusterManager *clusterV1alpha1.ClusterManager, upgradeTemplate provider.UpgradeTemplate) error {
+	if upgradeTemplate.Object != nil {
+		return r.createMachineTemplate(clusterManager, upgradeTemplate.Object)
+	}
+	log := r.Log.WithValues("clustermanager", clusterManager.GetNamespacedName())
 
-	// controlplane template instance 생성
-	controlplaneInstanceName := fmt.Sprintf("%s-controlplane-%s", clusterManager.Name, clusterManager.GetK8SVersion())
+	instanceName := upgradeTemplate.Name
 	key := types.NamespacedName{
-		Name:      controlplaneInstanceName,
+		Name:      instanceName,
 		Namespace: clusterManager.Namespace,
 	}
-
 	if err := r.Client.Get(context.TODO(), key, &tmaxv1.TemplateInstance{}); errors.IsNotFound(err) {
-		upgradeParams := buildVsphereUpgradeParams(*clusterManager)
-		templateInstance, err := ConstructTemplateInstance(clusterManager, controlplaneInstanceName, upgradeParams, true)
+		templateInstance, err := ConstructTemplateInstance(clusterManager, instanceName, upgradeTemplate.TemplateName, upgradeTemplate.Parameters)
 		if err != nil {
 			log.Error(err, "Failed to construct TemplateInstance")
-			return ctrl.Result{}, err
+			return err
 		}
 		ctrl.SetControllerReference(clusterManager, templateInstance, r.Scheme)
 		if err = r.Create(context.TODO(), templateInstance); err != nil {
 			log.Error(err, "Failed to create TemplateInstance")
-			return ctrl.Result{}, err
+			return err
 		}
-		log.Info("Created UpgradeTemplateInstance for controlplane successfully")
+		log.Info("Created UpgradeTemplateInstance successfully", "templateInstance", instanceName)
 	} else if err != nil {
 		log.Error(err, "Failed to get TemplateInstance")
-		return ctrl.Result{}, err
+		return err
 	}
+	return nil
+}
 
-	// worker template instance 생성
-	workerInstanceName := fmt.Sprintf("%s-worker-%s", clusterManager.Name, clusterManager.GetK8SVersion())
-	key = types.NamespacedName{
-		Name:      workerInstanceName,
-		Namespace: clusterManager.Namespace,
+// createMachineTemplate은 provider가 만든 machine template을 생성한다.
+// cluster manager가 삭제될 때 함께 삭제되도록 owner reference를 설정한다.
+func (r *ClusterManagerReconciler) createMachineTemplate(clusterManager *clusterV1alpha1.ClusterManager, machineTemplate *unstructured.Unstructured) error {
+	log := r.Log.WithValues("clustermanager", clusterManager.GetNamespacedName())
+
+	ctrl.SetControllerReference(clusterManager, machineTemplate, r.Scheme)
+	if err := r.Create(context.TODO(), machineTemplate); errors.IsAlreadyExists(err) {
+		return nil
+	} else if err != nil {
+		log.Error(err, "Failed to create machine template", "kind", machineTemplate.GetKind(), "name", machineTemplate.GetName())
+		return err
 	}
+	log.Info("Created machine template successfully", "kind", machineTemplate.GetKind(), "name", machineTemplate.GetName())
+	return nil
+}
 
-	if err := r.Client.Get(context.TODO(), key, &tmaxv1.TemplateInstance{}); errors.IsNotFound(err) {
-		upgradeParams := buildVsphereUpgradeParams(*clusterManager)
-		templateInstance, err := ConstructTemplateInstance(clusterManager, workerInstanceName, upgradeParams, true)
-		if err != nil {
-			log.Error(err, "Failed to construct TemplateInstance")
+// ReconcileWorkerPools는 spec의 worker pool 목록에 맞춰 worker pool용 template instance를 생성하거나 삭제한다.
+// 기본 worker pool(md-0)은 cluster template instance로 생성되므로 status만 갱신한다.
+func (r *ClusterManagerReconciler) ReconcileWorkerPools(ctx context.Context, clusterManager *clusterV1alpha1.ClusterManager) (ctrl.Result, error) {
+	if clusterManager.Annotations[clusterV1alpha1.AnnotationKeyClmSuffix] == "" {
+		return ctrl.Result{}, nil
+	}
+	log := r.Log.WithValues("clustermanager", clusterManager.GetNamespacedName())
+	log.Info("Start to reconcile phase for ReconcileWorkerPools")
+
+	p, err := provider.Get(clusterManager.Spec.Provider)
+	if err != nil {
+		return ctrl.Result{}, newTerminalError(clusterV1alpha1.FailureReasonInvalidConfiguration, err)
+	}
+
+	// 기본 worker pool의 replicas는 status.workerNum을 따른다.
+	if status := clusterManager.Status.GetWorkerPoolStatus(clusterV1alpha1.DefaultWorkerPoolName); status != nil {
+		status.Replicas = clusterManager.Status.WorkerNum
+	} else {
+		clusterManager.Status.SetWorkerPoolStatus(clusterV1alpha1.WorkerPoolStatus{
+			Name:     clusterV1alpha1.DefaultWorkerPoolName,
+			Replicas: clusterManager.Status.WorkerNum,
+		})
+	}
+
+	res := ctrl.Result{}
+	// 새로 추가되었거나 template이 변경된 worker pool
+	for _, pool := range clusterManager.Spec.WorkerPools {
+		status := clusterManager.Status.GetWorkerPoolStatus(pool.Name)
+		if status != nil && status.TemplateHash == "" {
+			// template hash를 기록하기 전에 생성된 pool은 현재 spec으로 생성된 것으로 본다.
+			status.TemplateHash = pool.TemplateHash()
+		}
+		if status != nil && status.TemplateHash == pool.TemplateHash() {
+			continue
+		}
+
+		instanceName := clusterManager.GetMachineDeploymentName(pool.Name)
+		key := types.NamespacedName{
+			Name:      instanceName,
+			Namespace: clusterManager.Namespace,
+		}
+		existing := &tmaxv1.TemplateInstance{}
+		if err := r.Client.Get(context.TODO(), key, existing); errors.IsNotFound(err) {
+			if mp, ok := p.(provider.MachineTemplateProvider); ok {
+				if err := r.createMachineTemplate(clusterManager, mp.WorkerMachineTemplate(clusterManager, pool)); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+			templateInstance, err := BuildWorkerPoolTemplateInstance(p, clusterManager, pool)
+			if err != nil {
+				log.Error(err, "Failed to construct TemplateInstance")
+				return ctrl.Result{}, newTerminalError(clusterV1alpha1.FailureReasonInvalidConfiguration, err)
+			}
+			ctrl.SetControllerReference(clusterManager, templateInstance, r.Scheme)
+			if err = r.Create(context.TODO(), templateInstance); err != nil {
+				log.Error(err, "Failed to create TemplateInstance")
+				return ctrl.Result{}, err
+			}
+			log.Info("Created TemplateInstance for worker pool successfully", "workerPool", pool.Name)
+		} else if err != nil {
+			log.Error(err, "Failed to get TemplateInstance")
 			return ctrl.Result{}, err
+		} else if !existing.DeletionTimestamp.IsZero() {
+			// 삭제 중인 template instance는 삭제가 끝난 후 다시 생성한다.
+			res = util.LowestNonZeroResult(res, ctrl.Result{RequeueAfter: requeueAfter10Second})
+			continue
+		} else if status != nil {
+			// 삭제 후 같은 이름으로 다시 추가되어 template이 변경된 pool은 기존 template instance를 삭제한 후 다시 생성한다.
+			if err := r.Delete(context.TODO(), existing); err != nil && !errors.IsNotFound(err) {
+				log.Error(err, "Failed to delete TemplateInstance")
+				return ctrl.Result{}, err
+			}
+			log.Info("Deleted TemplateInstance to replace worker pool", "workerPool", pool.Name)
+			res = util.LowestNonZeroResult(res, ctrl.Result{RequeueAfter: requeueAfter10Second})
+			continue
 		}
-		ctrl.SetControllerReference(clusterManager, templateInstance, r.Scheme)
-		if err = r.Create(context.TODO(), templateInstance); err != nil {
-			log.Error(err, "Failed to create TemplateInstance")
+
+		clusterManager.Status.SetWorkerPoolStatus(clusterV1alpha1.WorkerPoolStatus{
+			Name:         pool.Name,
+			Replicas:     pool.Replicas,
+			TemplateHash: pool.TemplateHash(),
+		})
+	}
+
+	// 삭제된 worker pool
+	removed := []string{}
+	for _, status := range clusterManager.Status.WorkerPools {
+		if status.Name == clusterV1alpha1.DefaultWorkerPoolName || clusterManager.GetWorkerPool(status.Name) != nil {
+			continue
+		}
+		removed = append(removed, status.Name)
+	}
+	for _, name := range removed {
+		templateInstance := &tmaxv1.TemplateInstance{}
+		key := types.NamespacedName{
+			Name:      clusterManager.GetMachineDeploymentName(name),
+			Namespace: clusterManager.Namespace,
+		}
+		if err := r.Client.Get(context.TODO(), key, templateInstance); err == nil {
+			if err := r.Delete(context.TODO(), templateInstance); err != nil && !errors.IsNotFound(err) {
+				log.Error(err, "Failed to delete TemplateInstance")
+				return ctrl.Result{}, err
+			}
+			log.Info("Deleted TemplateInstance for worker pool successfully", "workerPool", name)
+		} else if !errors.IsNotFound(err) {
+			log.Error(err, "Failed to get TemplateInstance")
 			return ctrl.Result{}, err
 		}
-		log.Info("Created UpgradeTemplateInstance for worker successfully")
-	} else if err != nil {
-		log.Error(err, "Failed to get TemplateInstance")
-		return ctrl.Result{}, err
+		clusterManager.Status.RemoveWorkerPoolStatus(name)
 	}
 
-	return ctrl.Result{}, nil
+	return res, nil
 }
 
 func (r *ClusterManagerReconciler) SetEndpoint(ctx context.Context, clusterManager *clusterV1alpha1.ClusterManager) (ctrl.Result, error) {
@@ -321,9 +480,15 @@ func (r *ClusterManagerReconciler) SetEndpoint(ctx context.Context, clusterManag
 
 	if cluster.Spec.ControlPlaneEndpoint.Host == "" {
 		log.Info("ControlPlane endpoint is not ready yet. requeue after 20sec")
+		clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeInfrastructureReady, clusterV1alpha1.ConditionReasonWaitingForEndpoint, "")
 		return ctrl.Result{RequeueAfter: requeueAfter20Second}, nil
 	}
 	clusterManager.Annotations[clusterV1alpha1.AnnotationKeyClmApiserver] = cluster.Spec.ControlPlaneEndpoint.Host
+	clusterManager.MarkConditionTrue(
+		clusterV1alpha1.ConditionTypeInfrastructureReady,
+		clusterV1alpha1.ConditionReasonInfrastructureReady,
+		"ControlPlane endpoint is "+cluster.Spec.ControlPlaneEndpoint.Host,
+	)
 
 	return ctrl.Result{}, nil
 }
@@ -348,6 +513,11 @@ func (r *ClusterManagerReconciler) ScaleControlplane(ctx context.Context, cluste
 	}
 
 	expectedNum := int32(clusterManager.Spec.MasterNum)
+	clusterManager.MarkConditionTrue(
+		clusterV1alpha1.ConditionTypeScaling,
+		clusterV1alpha1.ConditionReasonScalingControlPlane,
+		fmt.Sprintf("Scaling controlplane nodes (%d/%d)", kcp.Status.ReadyReplicas, expectedNum),
+	)
 	if *kcp.Spec.Replicas != expectedNum {
 		*kcp.Spec.Replicas = expectedNum
 		if err := r.Update(context.TODO(), kcp); err != nil {
@@ -360,6 +530,7 @@ func (r *ClusterManagerReconciler) ScaleControlplane(ctx context.Context, cluste
 	if kcp.Status.ReadyReplicas == *kcp.Spec.Replicas {
 		log.Info("Controlplane scaling is completed successfully")
 		clusterManager.Status.MasterNum = clusterManager.Spec.MasterNum
+		clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeScaling, clusterV1alpha1.ConditionReasonScalingCompleted, "")
 		return ctrl.Result{}, nil
 	}
 	if clusterManager.Spec.MasterNum > clusterManager.Status.MasterNum {
@@ -371,43 +542,58 @@ func (r *ClusterManagerReconciler) ScaleControlplane(ctx context.Context, cluste
 }
 
 // worker를 scaling한다.
+// replicas가 변경된 worker pool의 machinedeployment를 모두 갱신하고, 모든 pool이 준비될 때까지 기다린다.
 func (r *ClusterManagerReconciler) ScaleWorker(ctx context.Context, clusterManager *clusterV1alpha1.ClusterManager) (ctrl.Result, error) {
 	log := r.Log.WithValues("clustermanager", clusterManager.GetNamespacedName())
 	log.Info("Start to reconcile phase for WorkerScaling")
 
-	key := types.NamespacedName{
-		Name:      clusterManager.Name + "-md-0",
-		Namespace: clusterManager.Namespace,
-	}
-
-	md := &capiV1alpha3.MachineDeployment{}
-	if err := r.Client.Get(context.TODO(), key, md); errors.IsNotFound(err) {
-		return ctrl.Result{}, nil
-	} else if err != nil {
-		log.Error(err, "Failed to get machineDeployment")
-		return ctrl.Result{}, err
-	}
+	updated := false
+	readyNum, expectedNum := int32(0), int32(0)
+	for _, pool := range clusterManager.GetWorkerPools() {
+		key := types.NamespacedName{
+			Name:      clusterManager.GetMachineDeploymentName(pool.Name),
+			Namespace: clusterManager.Namespace,
+		}
 
-	expectedNum := int32(clusterManager.Spec.WorkerNum)
-	if *md.Spec.Replicas != expectedNum {
-		*md.Spec.Replicas = expectedNum
-		if err := r.Update(context.TODO(), md); err != nil {
-			log.Info("Failed to update machineDeployment")
+		md := &capiV1alpha3.MachineDeployment{}
+		if err := r.Client.Get(context.TODO(), key, md); errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			log.Error(err, "Failed to get machineDeployment")
 			return ctrl.Result{}, err
 		}
-		return ctrl.Result{RequeueAfter: requeueAfter20Second}, nil
+
+		expectedNum += int32(pool.Replicas)
+		readyNum += md.Status.ReadyReplicas
+		if *md.Spec.Replicas != int32(pool.Replicas) {
+			*md.Spec.Replicas = int32(pool.Replicas)
+			if err := r.Update(context.TODO(), md); err != nil {
+				log.Info("Failed to update machineDeployment")
+				return ctrl.Result{}, err
+			}
+			updated = true
+		} else if md.Status.ReadyReplicas != *md.Spec.Replicas {
+			updated = true
+		}
 	}
 
-	if md.Status.ReadyReplicas == *md.Spec.Replicas {
+	clusterManager.MarkConditionTrue(
+		clusterV1alpha1.ConditionTypeScaling,
+		clusterV1alpha1.ConditionReasonScalingWorker,
+		fmt.Sprintf("Scaling worker nodes (%d/%d)", readyNum, expectedNum),
+	)
+	if !updated {
 		log.Info("Worker scaling is completed successfully")
 		clusterManager.Status.WorkerNum = clusterManager.Spec.WorkerNum
+		for _, pool := range clusterManager.GetWorkerPools() {
+			if status := clusterManager.Status.GetWorkerPoolStatus(pool.Name); status != nil {
+				status.Replicas = pool.Replicas
+			}
+		}
+		clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeScaling, clusterV1alpha1.ConditionReasonScalingCompleted, "")
 		return ctrl.Result{}, nil
 	}
-	if clusterManager.Spec.WorkerNum > clusterManager.Status.WorkerNum {
-		log.Info("Waiting for Worker nodes to be scaled out. Requeue after 20sec.")
-	} else {
-		log.Info("Waiting for Worker nodes to be scaled in. Requeue after 20sec.")
-	}
+	log.Info("Waiting for Worker nodes to be scaled. Requeue after 20sec.")
 	return ctrl.Result{RequeueAfter: requeueAfter20Second}, nil
 }
 
@@ -416,39 +602,32 @@ func (r *ClusterManagerReconciler) ScaleWorker(ctx context.Context, clusterManag
 func (r *ClusterManagerReconciler) UpgradeCluster(ctx context.Context, clusterManager *clusterV1alpha1.ClusterManager) (ctrl.Result, error) {
 	log := r.Log.WithValues("clustermanager", clusterManager.GetNamespacedName())
 	log.Info("Start to reconcile phase for ClusterUpgrade")
+	clusterManager.MarkConditionTrue(
+		clusterV1alpha1.ConditionTypeUpgrading,
+		clusterV1alpha1.ConditionReasonUpgradingControlPlane,
+		"Upgrading to "+clusterManager.GetK8SVersion(),
+	)
 
-	if clusterManager.Spec.Provider == clusterV1alpha1.ProviderVSphere {
-		// template instance 체크 for controlplane
-		templateInstanceName := fmt.Sprintf("%s-controlplane-%s", clusterManager.Name, clusterManager.GetK8SVersion())
-		key := types.NamespacedName{
-			Name:      templateInstanceName,
-			Namespace: clusterManager.Namespace,
-		}
-
-		templateinstance := &tmaxv1.TemplateInstance{}
-		if err := r.Client.Get(context.TODO(), key, templateinstance); errors.IsNotFound(err) {
-			log.Info("Waiting for vsphere upgrade templateinstance(controlplane) to be created")
-			return ctrl.Result{RequeueAfter: requeueAfter10Second}, nil
-		} else if err != nil {
-			log.Error(err, "Failed to get templateinstance")
-			return ctrl.Result{}, err
-		}
+	p, err := provider.Get(clusterManager.Spec.Provider)
+	if err != nil {
+		return ctrl.Result{}, newTerminalError(clusterV1alpha1.FailureReasonInvalidConfiguration, err)
+	}
+	upgradePlan := p.PrepareUpgrade(clusterManager)
 
-		if !checkTemplateInstanceDeployed(templateinstance) {
-			log.Info("Waiting for vsphere upgrade templateinstance(controlplane) to be provisioned")
-			return ctrl.Result{RequeueAfter: requeueAfter10Second}, nil
+	// upgrade용 template instance 체크
+	// 직접 생성하는 machine template은 CreateUpgradeTemplateInstance에서 생성이 완료되었으므로 확인하지 않는다.
+	for _, upgradeTemplate := range upgradePlan.Templates() {
+		if upgradeTemplate.Object != nil {
+			continue
 		}
-
-		// template instance 체크 for worker
-		templateInstanceName = fmt.Sprintf("%s-worker-%s", clusterManager.Name, clusterManager.GetK8SVersion())
-		key = types.NamespacedName{
-			Name:      templateInstanceName,
+		key := types.NamespacedName{
+			Name:      upgradeTemplate.Name,
 			Namespace: clusterManager.Namespace,
 		}
 
-		templateinstance = &tmaxv1.TemplateInstance{}
+		templateinstance := &tmaxv1.TemplateInstance{}
 		if err := r.Client.Get(context.TODO(), key, templateinstance); errors.IsNotFound(err) {
-			log.Info("Waiting for vsphere upgrade templateinstance(worker) to be created")
+			log.Info("Waiting for upgrade templateinstance to be created", "templateInstance", upgradeTemplate.Name)
 			return ctrl.Result{RequeueAfter: requeueAfter10Second}, nil
 		} else if err != nil {
 			log.Error(err, "Failed to get templateinstance")
@@ -456,7 +635,7 @@ func (r *ClusterManagerReconciler) UpgradeCluster(ctx context.Context, clusterMa
 		}
 
 		if !checkTemplateInstanceDeployed(templateinstance) {
-			log.Info("Waiting for vsphere upgrade templateinstance(worker) to be provisioned")
+			log.Info("Waiting for upgrade templateinstance to be provisioned", "templateInstance", upgradeTemplate.Name)
 			return ctrl.Result{RequeueAfter: requeueAfter10Second}, nil
 		}
 	}
@@ -478,9 +657,13 @@ func (r *ClusterManagerReconciler) UpgradeCluster(ctx context.Context, clusterMa
 
 	// 단일 트랜잭션으로 업데이트 필요
 	if kcp.Spec.Version != clusterManager.GetK8SVersion() {
+		// remote cluster가 upgrade할 수 있는 상태인지 먼저 검사한다.
+		if res, err := r.checkUpgradePreflight(ctx, clusterManager); err != nil || !res.IsZero() {
+			return res, err
+		}
 		kcp.Spec.Version = clusterManager.GetK8SVersion()
-		if clusterManager.Spec.Provider == clusterV1alpha1.ProviderVSphere {
-			kcp.Spec.InfrastructureTemplate.Name = fmt.Sprintf("%s-controlplane-%s", clusterManager.Name, clusterManager.GetK8SVersion())
+		if upgradePlan.ControlPlane != nil {
+			kcp.Spec.InfrastructureTemplate.Name = upgradePlan.ControlPlane.Name
 		}
 		if err := r.Update(context.TODO(), kcp); err != nil {
 			log.Error(err, "Failed to update kubeadmcontrolplane")
@@ -498,35 +681,46 @@ func (r *ClusterManagerReconciler) UpgradeCluster(ctx context.Context, clusterMa
 
 	if len(machines.NewMachineRunningList) == clusterManager.Spec.MasterNum {
 		log.Info(fmt.Sprintf("Controlplane nodes upgraded successfully (%d/%d)", len(machines.NewMachineRunningList), clusterManager.Spec.MasterNum))
+		clusterManager.MarkConditionTrue(
+			clusterV1alpha1.ConditionTypeUpgrading,
+			clusterV1alpha1.ConditionReasonUpgradingWorker,
+			"Upgrading to "+clusterManager.GetK8SVersion(),
+		)
 	} else {
 		log.Info(fmt.Sprintf("Controlplane nodes are upgrading (%d/%d)", len(machines.NewMachineRunningList), clusterManager.Spec.MasterNum))
 		log.Info(fmt.Sprintf("Need to upgrade machine: [%s]. Requeue After 1 min", strings.Join(machines.OldMachineList, ", ")))
 		return ctrl.Result{RequeueAfter: requeueAfter1Minute}, nil
 	}
 
-	// 2. machineDeployment 업데이트
-	key = types.NamespacedName{
-		Name:      clusterManager.Name + "-md-0",
-		Namespace: clusterManager.Namespace,
-	}
-
-	md := &capiV1alpha3.MachineDeployment{}
-	if err := r.Client.Get(context.TODO(), key, md); errors.IsNotFound(err) {
-		return ctrl.Result{}, nil
-	} else if err != nil {
-		log.Error(err, "Failed to get machineDeployment")
-		return ctrl.Result{}, err
-	}
-
-	if *md.Spec.Template.Spec.Version != clusterManager.GetK8SVersion() {
-		*md.Spec.Template.Spec.Version = clusterManager.GetK8SVersion()
-		if clusterManager.Spec.Provider == clusterV1alpha1.ProviderVSphere {
-			md.Spec.Template.Spec.InfrastructureRef.Name = fmt.Sprintf("%s-worker-%s", clusterManager.Name, clusterManager.GetK8SVersion())
+	// 2. worker pool별 machineDeployment 업데이트
+	mdUpdated := false
+	for _, pool := range clusterManager.GetWorkerPools() {
+		key = types.NamespacedName{
+			Name:      clusterManager.GetMachineDeploymentName(pool.Name),
+			Namespace: clusterManager.Namespace,
 		}
-		if err := r.Update(context.TODO(), md); err != nil {
-			log.Error(err, "Failed to update machinedeployment")
+
+		md := &capiV1alpha3.MachineDeployment{}
+		if err := r.Client.Get(context.TODO(), key, md); errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			log.Error(err, "Failed to get machineDeployment")
 			return ctrl.Result{}, err
 		}
+
+		if *md.Spec.Template.Spec.Version != clusterManager.GetK8SVersion() {
+			*md.Spec.Template.Spec.Version = clusterManager.GetK8SVersion()
+			if upgradeTemplate := upgradePlan.WorkerPools[pool.Name]; upgradeTemplate != nil {
+				md.Spec.Template.Spec.InfrastructureRef.Name = upgradeTemplate.Name
+			}
+			if err := r.Update(context.TODO(), md); err != nil {
+				log.Error(err, "Failed to update machinedeployment")
+				return ctrl.Result{}, err
+			}
+			mdUpdated = true
+		}
+	}
+	if mdUpdated {
 		return ctrl.Result{RequeueAfter: requeueAfter10Second}, nil
 	}
 
@@ -537,15 +731,20 @@ func (r *ClusterManagerReconciler) UpgradeCluster(ctx context.Context, clusterMa
 		return ctrl.Result{RequeueAfter: requeueAfter10Second}, nil
 	}
 
-	if len(machines.NewMachineRunningList) == clusterManager.Spec.WorkerNum {
-		log.Info(fmt.Sprintf("worker nodes upgraded successfully (%d/%d)", len(machines.NewMachineRunningList), clusterManager.Spec.WorkerNum))
+	if len(machines.NewMachineRunningList) == clusterManager.GetTotalWorkerNum() {
+		log.Info(fmt.Sprintf("worker nodes upgraded successfully (%d/%d)", len(machines.NewMachineRunningList), clusterManager.GetTotalWorkerNum()))
 	} else {
-		log.Info(fmt.Sprintf("worker nodes are upgrading (%d/%d)", len(machines.NewMachineRunningList), clusterManager.Spec.WorkerNum))
+		log.Info(fmt.Sprintf("worker nodes are upgrading (%d/%d)", len(machines.NewMachineRunningList), clusterManager.GetTotalWorkerNum()))
 		log.Info(fmt.Sprintf("Need to upgrade machine: [%s]. Requeue After 1 min", strings.Join(machines.OldMachineList, ", ")))
 		return ctrl.Result{RequeueAfter: requeueAfter1Minute}, nil
 	}
 
 	clusterManager.Status.SetK8SVersion(clusterManager.Spec.Version)
+	clusterManager.MarkConditionFalse(
+		clusterV1alpha1.ConditionTypeUpgrading,
+		clusterV1alpha1.ConditionReasonUpgradeCompleted,
+		"Upgraded to "+clusterManager.GetK8SVersion(),
+	)
 	log.Info("Cluster upgradeded successfully")
 	return ctrl.Result{}, nil
 }
@@ -584,36 +783,38 @@ func (r *ClusterManagerReconciler) KubeadmControlPlaneUpdate(ctx context.Context
 	return ctrl.Result{}, nil
 }
 
-// MachineDeploymentUpdate는 cluster manager의 worker num와 machinedeployment의 replicas를 비교하여
-// machinedeployment의 replicas를 cluster manager의 worker num으로 업데이트한다.
+// MachineDeploymentUpdate는 worker pool의 replicas와 machinedeployment의 replicas를 비교하여
+// machinedeployment의 replicas를 worker pool의 replicas로 업데이트한다.
 func (r *ClusterManagerReconciler) MachineDeploymentUpdate(ctx context.Context, clusterManager *clusterV1alpha1.ClusterManager) (ctrl.Result, error) {
 	// scaling 하는 경우에 대해서는 실행하지 않음
-	if clusterManager.Spec.WorkerNum != clusterManager.Status.WorkerNum {
+	if clusterManager.Spec.WorkerNum != clusterManager.Status.WorkerNum || clusterManager.IsWorkerScaling() {
 		return ctrl.Result{}, nil
 	}
 
 	log := r.Log.WithValues("clustermanager", clusterManager.GetNamespacedName())
 	log.Info("Start to reconcile phase for machineDeploymentUpdate")
 
-	key := types.NamespacedName{
-		Name:      clusterManager.Name + "-md-0",
-		Namespace: clusterManager.Namespace,
-	}
-	md := &capiV1alpha3.MachineDeployment{}
-	if err := r.Client.Get(context.TODO(), key, md); errors.IsNotFound(err) {
-		return ctrl.Result{}, nil
-	} else if err != nil {
-		log.Error(err, "Failed to get machineDeployment")
-		return ctrl.Result{}, err
-	}
-
-	if *md.Spec.Replicas != int32(clusterManager.Spec.WorkerNum) {
-		*md.Spec.Replicas = int32(clusterManager.Spec.WorkerNum)
-		if err := r.Client.Update(context.Background(), md); err != nil {
-			log.Error(err, "Failed to update machinedeployment")
+	for _, pool := range clusterManager.GetWorkerPools() {
+		key := types.NamespacedName{
+			Name:      clusterManager.GetMachineDeploymentName(pool.Name),
+			Namespace: clusterManager.Namespace,
+		}
+		md := &capiV1alpha3.MachineDeployment{}
+		if err := r.Client.Get(context.TODO(), key, md); errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			log.Error(err, "Failed to get machineDeployment")
 			return ctrl.Result{}, err
 		}
-		log.Info("Updated machinedeployment replicas", "replicas", *md.Spec.Replicas)
+
+		if *md.Spec.Replicas != int32(pool.Replicas) {
+			*md.Spec.Replicas = int32(pool.Replicas)
+			if err := r.Client.Update(context.Background(), md); err != nil {
+				log.Error(err, "Failed to update machinedeployment")
+				return ctrl.Result{}, err
+			}
+			log.Info("Updated machinedeployment replicas", "machinedeployment", md.Name, "replicas", *md.Spec.Replicas)
+		}
 	}
 
 	return ctrl.Result{}, nil
@@ -654,6 +855,7 @@ func (r *ClusterManagerReconciler) CreateArgocdResources(ctx context.Context, cl
 		Get(context.TODO(), util.ArgoServiceAccountTokenSecret, metav1.GetOptions{})
 	if errors.IsNotFound(err) {
 		log.Info("Service account secret not found. Wait for creating")
+		clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeArgoReady, clusterV1alpha1.ConditionReasonArgoTokenNotReady, "")
 		return ctrl.Result{RequeueAfter: requeueAfter10Second}, nil
 	} else if err != nil {
 		log.Error(err, "Failed to get service account secret")
@@ -663,6 +865,7 @@ func (r *ClusterManagerReconciler) CreateArgocdResources(ctx context.Context, cl
 	// token secret이 잘들어가 있는지 check
 	if string(tokenSecret.Data["token"]) == "" {
 		log.Info("Service account secret token data not found. Wait for creating")
+		clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeArgoReady, clusterV1alpha1.ConditionReasonArgoTokenNotReady, "")
 		return ctrl.Result{Requeue: true}, nil
 	}
 
@@ -739,6 +942,7 @@ func (r *ClusterManagerReconciler) CreateArgocdResources(ctx context.Context, cl
 
 	log.Info("Create argocd cluster secret successfully")
 	clusterManager.Status.ArgoReady = true
+	clusterManager.MarkConditionTrue(clusterV1alpha1.ConditionTypeArgoReady, clusterV1alpha1.ConditionReasonArgoRegistered, "")
 
 	return ctrl.Result{}, nil
 }
@@ -771,6 +975,7 @@ func (r *ClusterManagerReconciler) CreateGatewayResources(ctx context.Context, c
 		Get(context.TODO(), "gateway", metav1.GetOptions{})
 	if errors.IsNotFound(err) {
 		log.Info("Cannot find Service for gateway. Wait for installing api-gateway. Requeue after 1 min")
+		clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeGatewayReady, clusterV1alpha1.ConditionReasonGatewayServiceNotFound, "")
 		return ctrl.Result{RequeueAfter: requeueAfter1Minute}, nil
 	} else if err != nil {
 		log.Error(err, "Failed to get Service for gateway")
@@ -787,6 +992,7 @@ func (r *ClusterManagerReconciler) CreateGatewayResources(ctx context.Context, c
 		if gatewayService.Status.LoadBalancer.Ingress == nil {
 			err := fmt.Errorf("service for gateway's type is not LoadBalancer or not ready")
 			log.Error(err, "Service for api-gateway is not Ready. Requeue after 1 min")
+			clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeGatewayReady, clusterV1alpha1.ConditionReasonGatewayServiceNotReady, err.Error())
 			return ctrl.Result{RequeueAfter: requeueAfter1Minute}, nil
 		}
 
@@ -795,6 +1001,7 @@ func (r *ClusterManagerReconciler) CreateGatewayResources(ctx context.Context, c
 		if hostnameOrIp == "" {
 			err := fmt.Errorf("service for gateway doesn't have both hostname and ip address")
 			log.Error(err, "Service for api-gateway is not Ready. Requeue after 1 min")
+			clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeGatewayReady, clusterV1alpha1.ConditionReasonGatewayServiceNotReady, err.Error())
 			return ctrl.Result{RequeueAfter: requeueAfter1Minute}, nil
 		}
 
@@ -837,6 +1044,7 @@ func (r *ClusterManagerReconciler) CreateGatewayResources(ctx context.Context, c
 	// }
 
 	clusterManager.Status.GatewayReady = true
+	clusterManager.MarkConditionTrue(clusterV1alpha1.ConditionTypeGatewayReady, clusterV1alpha1.ConditionReasonGatewayCreated, "")
 	return ctrl.Result{}, nil
 }
 
@@ -939,6 +1147,7 @@ func (r *ClusterManagerReconciler) CreateHyperAuthResources(ctx context.Context,
 	if !util.IsTrue(OIDC_CLIENT_SET) {
 		log.Info("Skip Creating oidc clients for single cluster")
 		clusterManager.Status.AuthClientReady = true
+		clusterManager.MarkConditionTrue(clusterV1alpha1.ConditionTypeAuthClientReady, clusterV1alpha1.ConditionReasonAuthClientSkipped, "")
 		return ctrl.Result{}, nil
 	}
 
@@ -966,6 +1175,7 @@ func (r *ClusterManagerReconciler) CreateHyperAuthResources(ctx context.Context,
 	for _, config := range clientConfigs {
 		if err := hyperauthCaller.CreateClient(config, secret); err != nil {
 			log.Error(err, "Failed to create hyperauth client ["+config.ClientId+"] for single cluster")
+			clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeAuthClientReady, clusterV1alpha1.ConditionReasonAuthClientFailed, err.Error())
 			return ctrl.Result{RequeueAfter: requeueAfter10Second}, err
 		}
 	}
@@ -975,6 +1185,7 @@ func (r *ClusterManagerReconciler) CreateHyperAuthResources(ctx context.Context,
 	for _, config := range protocolMapperMappingConfigs {
 		if err := hyperauthCaller.CreateClientLevelProtocolMapper(config, secret); err != nil {
 			log.Error(err, "Failed to create hyperauth protocol mapper ["+config.ClientId+"] for single cluster")
+			clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeAuthClientReady, clusterV1alpha1.ConditionReasonAuthClientFailed, err.Error())
 			return ctrl.Result{RequeueAfter: requeueAfter10Second}, err
 		}
 	}
@@ -984,12 +1195,14 @@ func (r *ClusterManagerReconciler) CreateHyperAuthResources(ctx context.Context,
 	for _, config := range clientLevelRoleConfigs {
 		if err := hyperauthCaller.CreateClientLevelRole(config, secret); err != nil {
 			log.Error(err, "Failed to create hyperauth client-level role ["+config.ClientId+"] for single cluster")
+			clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeAuthClientReady, clusterV1alpha1.ConditionReasonAuthClientFailed, err.Error())
 			return ctrl.Result{RequeueAfter: requeueAfter10Second}, err
 		}
 
 		userEmail := clusterManager.Annotations[util.AnnotationKeyOwner]
 		if err := hyperauthCaller.AddClientLevelRolesToUserRoleMapping(config, userEmail, secret); err != nil {
 			log.Error(err, "Failed to add client-level role to user role mapping ["+config.ClientId+"] for single cluster")
+			clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeAuthClientReady, clusterV1alpha1.ConditionReasonAuthClientFailed, err.Error())
 			return ctrl.Result{RequeueAfter: requeueAfter10Second}, err
 		}
 	}
@@ -1000,6 +1213,7 @@ func (r *ClusterManagerReconciler) CreateHyperAuthResources(ctx context.Context,
 		err := hyperauthCaller.AddClientScopeToClient(config, secret)
 		if err != nil {
 			log.Error(err, "Failed to add client scope to client ["+config.ClientId+"] for single cluster")
+			clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeAuthClientReady, clusterV1alpha1.ConditionReasonAuthClientFailed, err.Error())
 			return ctrl.Result{RequeueAfter: requeueAfter10Second}, err
 		}
 	}
@@ -1010,18 +1224,21 @@ func (r *ClusterManagerReconciler) CreateHyperAuthResources(ctx context.Context,
 		err := hyperauthCaller.CreateGroup(config, secret)
 		if err != nil {
 			log.Error(err, "Failed to create group ["+config.Name+"] for single cluster")
+			clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeAuthClientReady, clusterV1alpha1.ConditionReasonAuthClientFailed, err.Error())
 			return ctrl.Result{RequeueAfter: requeueAfter10Second}, err
 		}
 
 		err = hyperauthCaller.AddGroupToUser(clusterManager.Annotations[util.AnnotationKeyOwner], config, secret)
 		if err != nil {
 			log.Error(err, "Failed to add group to user ["+config.Name+"] for single cluster")
+			clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeAuthClientReady, clusterV1alpha1.ConditionReasonAuthClientFailed, err.Error())
 			return ctrl.Result{RequeueAfter: requeueAfter10Second}, err
 		}
 	}
 
 	log.Info("Create clients for single cluster successfully")
 	clusterManager.Status.AuthClientReady = true
+	clusterManager.MarkConditionTrue(clusterV1alpha1.ConditionTypeAuthClientReady, clusterV1alpha1.ConditionReasonAuthClientCreated, "")
 	return ctrl.Result{}, nil
 }
 
@@ -1105,18 +1322,22 @@ func (r *ClusterManagerReconciler) CreateTraefikResources(ctx context.Context, c
 	log.Info("Start to reconcile phase for CreateTraefikResources")
 
 	if err := r.CreateMiddleware(clusterManager); err != nil {
+		clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeTraefikReady, clusterV1alpha1.ConditionReasonTraefikFailed, err.Error())
 		return ctrl.Result{}, err
 	}
 
 	if err := r.CreateServiceAccountSecret(clusterManager); err != nil {
+		clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeTraefikReady, clusterV1alpha1.ConditionReasonTraefikFailed, err.Error())
 		return ctrl.Result{}, err
 	}
 
 	if err := r.CreateIngress(clusterManager); err != nil {
+		clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeTraefikReady, clusterV1alpha1.ConditionReasonTraefikFailed, err.Error())
 		return ctrl.Result{}, err
 	}
 
 	log.Info("Create traefik resources successfully")
 	clusterManager.Status.TraefikReady = true
+	clusterManager.MarkConditionTrue(clusterV1alpha1.ConditionTypeTraefikReady, clusterV1alpha1.ConditionReasonTraefikCreated, "")
 	return ctrl.Result{}, nil
 }
@@ -0,0 +1,223 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	util "github.com/tmax-cloud/hypercloud-multi-operator/controllers/util"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/kubernetes"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	upgradePreflightTimeout = 30 * time.Second
+	// api server가 시작된 이후 요청된 deprecated api를 기록하는 metric (kubernetes 1.19 이상)
+	deprecatedApisMetricName = "apiserver_requested_deprecated_apis"
+)
+
+// checkUpgradePreflight는 kcp를 변경하기 전에 pre-flight check를 수행하고 결과를 condition에 기록한다.
+// 통과하지 못하면 upgrade를 시작하지 않고 다시 검사하며, upgrade 제한 시간이 지나면 실패로 처리된다.
+func (r *ClusterManagerReconciler) checkUpgradePreflight(ctx context.Context, clusterManager *clusterV1alpha1.ClusterManager) (ctrl.Result, error) {
+	log := r.Log.WithValues("clustermanager", clusterManager.GetNamespacedName())
+
+	kubeconfigSecret, err := r.GetKubeconfigSecret(clusterManager)
+	if err != nil {
+		log.Error(err, "Failed to get kubeconfig secret")
+		return ctrl.Result{RequeueAfter: requeueAfter10Second}, nil
+	}
+	remoteClientset, err := util.GetRemoteK8sClient(kubeconfigSecret)
+	if err != nil {
+		log.Error(err, "Failed to get remoteK8sClient")
+		return ctrl.Result{}, err
+	}
+
+	failures, err := runUpgradePreflightChecks(ctx, remoteClientset, clusterManager.GetK8SVersion())
+	if err != nil {
+		log.Error(err, "Failed to run upgrade pre-flight checks")
+		failures = []string{"failed to run pre-flight checks: " + err.Error()}
+	}
+	if len(failures) > 0 {
+		message := strings.Join(failures, ", ")
+		log.Info("Upgrade pre-flight checks failed. Requeue after 1 min", "failures", message)
+		clusterManager.MarkConditionFalse(
+			clusterV1alpha1.ConditionTypeUpgradePreflightPassed,
+			clusterV1alpha1.ConditionReasonPreflightChecksFailed,
+			message,
+		)
+		clusterManager.MarkConditionTrue(
+			clusterV1alpha1.ConditionTypeUpgrading,
+			clusterV1alpha1.ConditionReasonWaitingForPreflightChecks,
+			"Upgrading to "+clusterManager.GetK8SVersion()+": "+message,
+		)
+		return ctrl.Result{RequeueAfter: requeueAfter1Minute}, nil
+	}
+
+	log.Info("Upgrade pre-flight checks passed")
+	clusterManager.MarkConditionTrue(
+		clusterV1alpha1.ConditionTypeUpgradePreflightPassed,
+		clusterV1alpha1.ConditionReasonPreflightChecksPassed,
+		"Ready to upgrade to "+clusterManager.GetK8SVersion(),
+	)
+	return ctrl.Result{}, nil
+}
+
+// runUpgradePreflightChecks는 upgrade를 시작하기 전에 remote cluster의 상태를 검사하고, 통과하지 못한 항목을 반환한다.
+// - 모든 node가 ready 상태여야 한다.
+// - drain을 막는 PodDisruptionBudget이 없어야 한다.
+// - target version에서 삭제되는 api를 사용하지 않아야 한다.
+func runUpgradePreflightChecks(ctx context.Context, remoteClientset *kubernetes.Clientset, targetVersion string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, upgradePreflightTimeout)
+	defer cancel()
+
+	failures := []string{}
+	for _, check := range []func(context.Context, *kubernetes.Clientset, string) ([]string, error){
+		checkNodesReady,
+		checkPodDisruptionBudgets,
+		checkDeprecatedApis,
+	} {
+		f, err := check(ctx, remoteClientset, targetVersion)
+		if err != nil {
+			return nil, err
+		}
+		failures = append(failures, f...)
+	}
+	return failures, nil
+}
+
+func checkNodesReady(ctx context.Context, remoteClientset *kubernetes.Clientset, _ string) ([]string, error) {
+	nodeList, err := remoteClientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	failures := []string{}
+	for _, node := range nodeList.Items {
+		ready := false
+		for _, cond := range node.Status.Conditions {
+			if cond.Type == coreV1.NodeReady && cond.Status == coreV1.ConditionTrue {
+				ready = true
+			}
+		}
+		if !ready {
+			failures = append(failures, fmt.Sprintf("node [%s] is not ready", node.Name))
+		}
+	}
+	return failures, nil
+}
+
+// checkPodDisruptionBudgets는 허용된 disruption이 없어 node drain을 막는 PodDisruptionBudget을 찾는다.
+// policy/v1을 지원하지 않는 1.21 미만의 cluster는 policy/v1beta1로 조회한다.
+func checkPodDisruptionBudgets(ctx context.Context, remoteClientset *kubernetes.Clientset, _ string) ([]string, error) {
+	type pdbStatus struct {
+		name               string
+		expectedPods       int32
+		disruptionsAllowed int32
+	}
+	pdbs := []pdbStatus{}
+
+	pdbList, err := remoteClientset.PolicyV1().PodDisruptionBudgets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if errors.IsNotFound(err) {
+		pdbListV1beta1, err := remoteClientset.PolicyV1beta1().PodDisruptionBudgets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, pdb := range pdbListV1beta1.Items {
+			pdbs = append(pdbs, pdbStatus{pdb.Namespace + "/" + pdb.Name, pdb.Status.ExpectedPods, pdb.Status.DisruptionsAllowed})
+		}
+	} else if err != nil {
+		return nil, err
+	} else {
+		for _, pdb := range pdbList.Items {
+			pdbs = append(pdbs, pdbStatus{pdb.Namespace + "/" + pdb.Name, pdb.Status.ExpectedPods, pdb.Status.DisruptionsAllowed})
+		}
+	}
+
+	failures := []string{}
+	for _, pdb := range pdbs {
+		if pdb.expectedPods > 0 && pdb.disruptionsAllowed == 0 {
+			failures = append(failures, fmt.Sprintf("PodDisruptionBudget [%s] allows no disruption", pdb.name))
+		}
+	}
+	return failures, nil
+}
+
+// checkDeprecatedApis는 api server의 metric에서 target version 이하에서 삭제되는 api가 요청되었는지 확인한다.
+// metric은 api server가 시작된 이후의 요청만 기록하므로, 요청이 드문 api는 찾지 못할 수 있다.
+func checkDeprecatedApis(ctx context.Context, remoteClientset *kubernetes.Clientset, targetVersion string) ([]string, error) {
+	target, err := version.ParseGeneric(targetVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics, err := remoteClientset.CoreV1().RESTClient().Get().AbsPath("/metrics").DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	failures := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(metrics))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, deprecatedApisMetricName+"{") {
+			continue
+		}
+		labels := parseMetricLabels(line)
+		removed, err := version.ParseGeneric(labels["removed_release"])
+		if err != nil || !target.AtLeast(removed) {
+			continue
+		}
+
+		api := labels["resource"] + "." + labels["version"]
+		if labels["group"] != "" {
+			api += "." + labels["group"]
+		}
+		failures = append(failures, fmt.Sprintf("api [%s] removed in %s is in use", api, labels["removed_release"]))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return failures, nil
+}
+
+// parseMetricLabels는 prometheus text format의 metric line에서 label을 추출한다.
+func parseMetricLabels(line string) map[string]string {
+	labels := map[string]string{}
+	start, end := strings.Index(line, "{"), strings.LastIndex(line, "}")
+	if start < 0 || end < start {
+		return labels
+	}
+	for _, pair := range strings.Split(line[start+1:end], ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		labels[strings.TrimSpace(kv[0])] = strings.Trim(kv[1], `"`)
+	}
+	return labels
+}
@@ -78,31 +78,6 @@ func (r *ClusterManagerReconciler) fetchArgocdIngressDomain(manager *clusterV1al
 	return subdomain[0], nil
 }
 
-// buildParam는 name과 value를 받아서 ParamSpec을 만들어 리턴
-// valueType이 intstr.String이면 value를 string으로 변환해서 리턴
-// valueType이 intstr.Int이면 value를 int로 변환해서 리턴(int만 가능)
-func buildParam(name string, value interface{}, valueType intstr.Type) tmaxv1.ParamSpec {
-	paramSpec := tmaxv1.ParamSpec{}
-	if valueType == intstr.String {
-		paramSpec.Name = name
-		paramSpec.Value = intstr.IntOrString{
-			Type:   intstr.String,
-			StrVal: value.(string),
-		}
-	} else if valueType == intstr.Int {
-		paramSpec.Name = name
-		value := int32(value.(int))
-		paramSpec.Value = intstr.IntOrString{
-			Type:   intstr.Int,
-			IntVal: value,
-		}
-	} else {
-		return tmaxv1.ParamSpec{}
-	}
-
-	return paramSpec
-}
-
 func mergeParams(paramsList ...[]tmaxv1.ParamSpec) []tmaxv1.ParamSpec {
 	var result []tmaxv1.ParamSpec
 	for _, params := range paramsList {
@@ -118,15 +93,24 @@ func (r *ClusterManagerReconciler) GetMachineList(clusterManager *clusterV1alpha
 		client.MatchingLabels{CAPI_CLUSTER_LABEL_KEY: clusterManager.Name}}
 
 	if controlplane {
-		opts = append(opts, client.MatchingLabels{CAPI_CONTROLPLANE_LABEL_KEY: ""})
-	} else {
-		opts = append(opts, client.MatchingLabels{CAPI_WORKER_LABEL_KEY: clusterManager.Name + "-md-0"})
+		opts = append(opts, client.MatchingLabels{CAPI_CLUSTER_LABEL_KEY: clusterManager.Name, CAPI_CONTROLPLANE_LABEL_KEY: ""})
 	}
 	machines := &capiV1alpha3.MachineList{}
 	if err := r.List(context.TODO(), machines, opts...); err != nil {
 		return []capiV1alpha3.Machine{}, err
 	}
-	return machines.Items, nil
+	if controlplane {
+		return machines.Items, nil
+	}
+
+	// worker pool이 여러 개일 수 있으므로 machinedeployment에 속한 machine을 모두 반환한다.
+	workers := []capiV1alpha3.Machine{}
+	for _, machine := range machines.Items {
+		if _, ok := machine.Labels[CAPI_WORKER_LABEL_KEY]; ok {
+			workers = append(workers, machine)
+		}
+	}
+	return workers, nil
 }
 
 // controlplane machine list를 반환
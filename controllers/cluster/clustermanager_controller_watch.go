@@ -58,6 +58,11 @@ func (r *ClusterManagerReconciler) requeueClusterManagersForCluster(o client.Obj
 	}()
 	// clm.Status.SetTypedPhase(clusterV1alpha1.ClusterManagerPhaseProvisioned)
 	clm.Status.ControlPlaneReady = c.Status.ControlPlaneInitialized
+	if c.Status.ControlPlaneInitialized {
+		clm.MarkConditionTrue(clusterV1alpha1.ConditionTypeControlPlaneReady, clusterV1alpha1.ConditionReasonControlPlaneInitialized, "")
+	} else {
+		clm.MarkConditionFalse(clusterV1alpha1.ConditionTypeControlPlaneReady, clusterV1alpha1.ConditionReasonWaitingForControlPlane, "")
+	}
 
 	return nil
 }
@@ -156,8 +161,29 @@ func (r *ClusterManagerReconciler) requeueClusterManagersForMachineDeployment(o
 	}
 
 	// cluster manager status workerRun update
-	if clm.Status.WorkerRun != int(md.Status.ReadyReplicas) {
-		clm.Status.WorkerRun = int(md.Status.ReadyReplicas)
+	// worker pool이 여러 개일 수 있으므로 cluster의 모든 machinedeployment의 ready replicas를 합산한다.
+	mdList := &capiV1alpha3.MachineDeploymentList{}
+	opts := []client.ListOption{
+		client.InNamespace(md.Namespace),
+		client.MatchingLabels{LabelKeyCAPIClusterName: clusterName},
+	}
+	if err := r.Client.List(context.TODO(), mdList, opts...); err != nil {
+		log.Error(err, "Failed to list machinedeployments")
+		return nil
+	}
+	workerRun := 0
+	for _, item := range mdList.Items {
+		workerRun += int(item.Status.ReadyReplicas)
+	}
+
+	poolName := strings.TrimPrefix(md.Name, clusterName+"-")
+	poolStatus := clm.Status.GetWorkerPoolStatus(poolName)
+	poolStatusChanged := poolStatus != nil && poolStatus.ReadyReplicas != int(md.Status.ReadyReplicas)
+	if clm.Status.WorkerRun != workerRun || poolStatusChanged {
+		clm.Status.WorkerRun = workerRun
+		if poolStatus != nil {
+			poolStatus.ReadyReplicas = int(md.Status.ReadyReplicas)
+		}
 		err := r.Client.Status().Update(context.Background(), clm)
 		if err != nil {
 			log.Error(err, "Failed to update clusterManager")
@@ -168,8 +194,17 @@ func (r *ClusterManagerReconciler) requeueClusterManagersForMachineDeployment(o
 	}
 
 	// machine deployment spec replicas update
-	if md.Spec.Replicas != nil && *md.Spec.Replicas != int32(clm.Spec.WorkerNum) {
-		workerNum := int32(clm.Spec.WorkerNum)
+	var replicas int
+	if poolName == clusterV1alpha1.DefaultWorkerPoolName {
+		replicas = clm.Spec.WorkerNum
+	} else if pool := clm.GetWorkerPool(poolName); pool != nil {
+		replicas = pool.Replicas
+	} else {
+		// 삭제 중이거나 cluster manager가 관리하지 않는 machinedeployment
+		return nil
+	}
+	if md.Spec.Replicas != nil && *md.Spec.Replicas != int32(replicas) {
+		workerNum := int32(replicas)
 		md.Spec.Replicas = &workerNum
 		err := r.Client.Update(context.Background(), md)
 		// TODO : conflict error 처리
@@ -210,10 +245,13 @@ func (r *ClusterManagerReconciler) requeueClusterManagersForSubresources(o clien
 	}
 
 	isGateway := strings.Contains(o.GetName(), "gateway")
+	message := o.GetName() + " is deleted"
 	if isGateway {
 		clm.Status.GatewayReady = false
+		clm.MarkConditionFalse(clusterV1alpha1.ConditionTypeGatewayReady, clusterV1alpha1.ConditionReasonSubresourceLost, message)
 	} else {
 		clm.Status.TraefikReady = false
+		clm.MarkConditionFalse(clusterV1alpha1.ConditionTypeTraefikReady, clusterV1alpha1.ConditionReasonSubresourceLost, message)
 	}
 
 	err := r.Status().Update(context.TODO(), clm)
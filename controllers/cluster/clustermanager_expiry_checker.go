@@ -0,0 +1,155 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	claimV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/claim/v1alpha1"
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	DefaultExpiryCheckInterval = 1 * time.Minute
+	DefaultExpiryWarningPeriod = 24 * time.Hour
+)
+
+// ClusterExpiryChecker는 주기적으로 만료 시각이 지정된 cluster manager를 확인한다.
+// 만료 전 WarningPeriod 이내가 되면 event와 annotation으로 경고하고, 만료되면 cluster manager를 삭제한다.
+type ClusterExpiryChecker struct {
+	client.Client
+	Log           logr.Logger
+	Recorder      record.EventRecorder
+	Interval      time.Duration
+	WarningPeriod time.Duration
+}
+
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=claim.tmax.io,resources=clusterclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups=claim.tmax.io,resources=clusterclaims/status,verbs=get;update;patch
+
+func (c *ClusterExpiryChecker) SetupWithManager(mgr ctrl.Manager) error {
+	if c.Interval <= 0 {
+		c.Interval = DefaultExpiryCheckInterval
+	}
+	if c.WarningPeriod <= 0 {
+		c.WarningPeriod = DefaultExpiryWarningPeriod
+	}
+	return mgr.Add(c)
+}
+
+// NeedLeaderElection은 leader인 manager에서만 확인하도록 한다.
+func (c *ClusterExpiryChecker) NeedLeaderElection() bool {
+	return true
+}
+
+// Start는 manager가 종료될 때까지 Interval마다 만료된 cluster를 확인한다.
+func (c *ClusterExpiryChecker) Start(ctx context.Context) error {
+	c.Log.Info("Start cluster expiry checker", "interval", c.Interval, "warningPeriod", c.WarningPeriod)
+	wait.UntilWithContext(ctx, c.check, c.Interval)
+	return nil
+}
+
+func (c *ClusterExpiryChecker) check(ctx context.Context) {
+	clmList := &clusterV1alpha1.ClusterManagerList{}
+	if err := c.List(ctx, clmList); err != nil {
+		c.Log.Error(err, "Failed to list ClusterManagers")
+		return
+	}
+
+	now := time.Now()
+	for i := range clmList.Items {
+		clm := &clmList.Items[i]
+		// paused인 cluster는 resume된 이후에 삭제한다.
+		// 삭제 보호된 cluster는 webhook이 삭제를 거부하므로 보호가 해제된 이후에 삭제한다.
+		if clm.Spec.ExpiresAt == nil || !clm.DeletionTimestamp.IsZero() || clm.Spec.Paused || clm.IsDeletionProtected() ||
+			clm.GetClusterType() != clusterV1alpha1.ClusterTypeCreated {
+			continue
+		}
+
+		expiresAt := clm.Spec.ExpiresAt.Time
+		if !now.Before(expiresAt) {
+			if err := c.expire(ctx, clm); err != nil {
+				c.Log.Error(err, "Failed to delete expired cluster", "clustermanager", clm.GetNamespacedName())
+			}
+		} else if now.Add(c.WarningPeriod).After(expiresAt) &&
+			clm.Annotations[clusterV1alpha1.AnnotationKeyClmExpiryWarning] != expiresAt.UTC().Format(time.RFC3339) {
+			if err := c.warn(ctx, clm); err != nil {
+				c.Log.Error(err, "Failed to warn cluster expiry", "clustermanager", clm.GetNamespacedName())
+			}
+		}
+	}
+}
+
+// warn은 만료 예정인 cluster manager에 event를 남기고, 경고한 만료 시각을 annotation에 기록한다.
+// 만료 시각이 연장되면 annotation 값과 달라지므로 다시 경고한다.
+func (c *ClusterExpiryChecker) warn(ctx context.Context, clusterManager *clusterV1alpha1.ClusterManager) error {
+	log := c.Log.WithValues("clustermanager", clusterManager.GetNamespacedName())
+	expiresAt := clusterManager.Spec.ExpiresAt.UTC().Format(time.RFC3339)
+	log.Info("Cluster will expire soon", "expiresAt", expiresAt)
+
+	c.Recorder.Eventf(clusterManager, coreV1.EventTypeWarning, "ClusterExpiring",
+		"Cluster will expire at %s and be deleted. Create ClusterUpdateClaim of Extension type to extend", expiresAt)
+
+	before := clusterManager.DeepCopy()
+	if clusterManager.Annotations == nil {
+		clusterManager.Annotations = map[string]string{}
+	}
+	clusterManager.Annotations[clusterV1alpha1.AnnotationKeyClmExpiryWarning] = expiresAt
+	return c.Patch(ctx, clusterManager, client.MergeFrom(before))
+}
+
+// expire는 cluster claim의 phase를 expired로 변경하고, cluster manager를 삭제한다.
+// cluster는 cluster manager의 삭제 처리(reconcileDelete)를 통해 삭제된다.
+func (c *ClusterExpiryChecker) expire(ctx context.Context, clusterManager *clusterV1alpha1.ClusterManager) error {
+	log := c.Log.WithValues("clustermanager", clusterManager.GetNamespacedName())
+	expiresAt := clusterManager.Spec.ExpiresAt.UTC().Format(time.RFC3339)
+	log.Info("Cluster is expired. Start to delete cluster", "expiresAt", expiresAt)
+
+	// cluster claim은 cluster manager가 삭제되면 cluster deleted로 변경되므로 먼저 변경한다.
+	key := types.NamespacedName{
+		Name:      clusterManager.Labels[clusterV1alpha1.LabelKeyClcName],
+		Namespace: clusterManager.Namespace,
+	}
+	cc := &claimV1alpha1.ClusterClaim{}
+	if err := c.Get(ctx, key, cc); err != nil && !errors.IsNotFound(err) {
+		return err
+	} else if err == nil && cc.Status.Phase == claimV1alpha1.ClusterClaimPhaseApproved {
+		cc.Status.SetTypedPhase(claimV1alpha1.ClusterClaimPhaseExpired)
+		cc.Status.SetReason(fmt.Sprintf("cluster is expired at %s", expiresAt))
+		if err := c.Status().Update(ctx, cc); err != nil {
+			return err
+		}
+	}
+
+	c.Recorder.Eventf(clusterManager, coreV1.EventTypeNormal, "ClusterExpired",
+		"Cluster is expired at %s. Deleting cluster", expiresAt)
+	if err := c.Delete(ctx, clusterManager); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	util "github.com/tmax-cloud/hypercloud-multi-operator/controllers/util"
+
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	DefaultHealthProbeInterval         = 30 * time.Second
+	DefaultHealthProbeFailureThreshold = 3
+	healthProbeTimeout                 = 10 * time.Second
+)
+
+// ClusterHealthProber는 주기적으로 cluster manager가 관리하는 cluster의 api server 상태를 확인한다.
+// FailureThreshold번 연속으로 실패하면 cluster를 unhealthy로 표시하고, 다시 응답하면 자동으로 복구한다.
+type ClusterHealthProber struct {
+	client.Client
+	Log              logr.Logger
+	Interval         time.Duration
+	FailureThreshold int
+
+	// cluster별 연속 실패 횟수
+	failures map[types.NamespacedName]int
+}
+
+func (p *ClusterHealthProber) SetupWithManager(mgr ctrl.Manager) error {
+	if p.Interval <= 0 {
+		p.Interval = DefaultHealthProbeInterval
+	}
+	if p.FailureThreshold <= 0 {
+		p.FailureThreshold = DefaultHealthProbeFailureThreshold
+	}
+	p.failures = map[types.NamespacedName]int{}
+	return mgr.Add(p)
+}
+
+// NeedLeaderElection은 leader인 manager에서만 확인하도록 한다.
+func (p *ClusterHealthProber) NeedLeaderElection() bool {
+	return true
+}
+
+// Start는 manager가 종료될 때까지 Interval마다 cluster 상태를 확인한다.
+func (p *ClusterHealthProber) Start(ctx context.Context) error {
+	p.Log.Info("Start cluster health prober", "interval", p.Interval, "failureThreshold", p.FailureThreshold)
+	wait.UntilWithContext(ctx, p.probe, p.Interval)
+	return nil
+}
+
+func (p *ClusterHealthProber) probe(ctx context.Context) {
+	clmList := &clusterV1alpha1.ClusterManagerList{}
+	if err := p.List(ctx, clmList); err != nil {
+		p.Log.Error(err, "Failed to list ClusterManagers")
+		return
+	}
+
+	probed := map[types.NamespacedName]bool{}
+	for i := range clmList.Items {
+		clm := &clmList.Items[i]
+		// 한번도 접근에 성공하지 못한 cluster는 생성/등록 단계에서 처리한다.
+		if !clm.DeletionTimestamp.IsZero() || !clm.Status.ControlPlaneReady {
+			continue
+		}
+		probed[clm.GetNamespacedName()] = true
+
+		probeErr := p.probeCluster(ctx, clm)
+		if err := p.updateHealth(ctx, clm, probeErr); err != nil {
+			p.Log.Error(err, "Failed to update cluster health", "clustermanager", clm.GetNamespacedName())
+		}
+	}
+
+	// 삭제된 cluster의 실패 횟수는 정리한다.
+	for key := range p.failures {
+		if !probed[key] {
+			delete(p.failures, key)
+		}
+	}
+}
+
+// probeCluster는 api server가 응답하는지, readyz가 ok인지 확인한다.
+func (p *ClusterHealthProber) probeCluster(ctx context.Context, clusterManager *clusterV1alpha1.ClusterManager) error {
+	key := types.NamespacedName{
+		Name:      clusterManager.Name + util.KubeconfigSuffix,
+		Namespace: clusterManager.Namespace,
+	}
+	kubeconfigSecret := &coreV1.Secret{}
+	if err := p.Get(ctx, key, kubeconfigSecret); err != nil {
+		return err
+	}
+
+	remoteClientset, err := util.GetRemoteK8sClientWithTimeout(kubeconfigSecret, healthProbeTimeout)
+	if err != nil {
+		return err
+	}
+
+	if !util.IsClusterHealthy(remoteClientset) {
+		return fmt.Errorf("api server is not responding")
+	}
+
+	resp, err := remoteClientset.
+		RESTClient().
+		Get().
+		AbsPath("/readyz").
+		DoRaw(ctx)
+	if err != nil {
+		return err
+	}
+	if string(resp) != "ok" {
+		return fmt.Errorf("readyz: %s", string(resp))
+	}
+	return nil
+}
+
+func (p *ClusterHealthProber) updateHealth(ctx context.Context, clusterManager *clusterV1alpha1.ClusterManager, probeErr error) error {
+	log := p.Log.WithValues("clustermanager", clusterManager.GetNamespacedName())
+	before := clusterManager.DeepCopy()
+	key := clusterManager.GetNamespacedName()
+
+	if probeErr == nil {
+		delete(p.failures, key)
+		now := metav1.Now()
+		clusterManager.Status.LastContactTime = &now
+		if !clusterManager.IsConditionTrue(clusterV1alpha1.ConditionTypeHealthy) {
+			log.Info("Cluster is responding")
+			clusterManager.MarkConditionTrue(clusterV1alpha1.ConditionTypeHealthy, clusterV1alpha1.ConditionReasonClusterResponding, "")
+		}
+		// unhealthy로 인해 ready가 아니었던 경우에만 복구한다.
+		if cond := clusterManager.GetCondition(clusterV1alpha1.ConditionTypeReady); cond != nil &&
+			cond.Reason == clusterV1alpha1.ConditionReasonClusterUnhealthy && clusterManager.Status.TraefikReady {
+			clusterManager.Status.Ready = true
+			clusterManager.MarkConditionTrue(clusterV1alpha1.ConditionTypeReady, clusterV1alpha1.ConditionReasonClusterReady, "")
+		}
+	} else {
+		p.failures[key]++
+		log.Info("Failed to probe cluster", "failures", p.failures[key], "reason", probeErr.Error())
+		if p.failures[key] < p.FailureThreshold {
+			return nil
+		}
+		// 매번 condition이 바뀌지 않도록 실패 횟수는 message에 포함하지 않는다.
+		message := fmt.Sprintf("cluster did not respond to %d consecutive probes: %s", p.FailureThreshold, probeErr.Error())
+		if clusterManager.IsConditionTrue(clusterV1alpha1.ConditionTypeHealthy) ||
+			clusterManager.GetCondition(clusterV1alpha1.ConditionTypeHealthy) == nil {
+			log.Info("Cluster is not responding. Mark cluster as unhealthy")
+		}
+		clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeHealthy, clusterV1alpha1.ConditionReasonClusterNotResponding, message)
+		clusterManager.Status.Ready = false
+		clusterManager.MarkConditionFalse(clusterV1alpha1.ConditionTypeReady, clusterV1alpha1.ConditionReasonClusterUnhealthy, message)
+	}
+
+	return p.Status().Patch(ctx, clusterManager, client.MergeFrom(before))
+}
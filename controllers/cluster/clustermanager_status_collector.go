@@ -0,0 +1,272 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	util "github.com/tmax-cloud/hypercloud-multi-operator/controllers/util"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	DefaultStatusCollectInterval = 1 * time.Minute
+	// 응답하지 않는 cluster로 인해 다른 cluster의 수집이 지연되지 않도록 cluster별 제한 시간을 둔다.
+	statusCollectTimeout = 20 * time.Second
+)
+
+// 수집하는 resource 종류
+var collectedResources = []coreV1.ResourceName{
+	coreV1.ResourceCPU,
+	coreV1.ResourceMemory,
+	coreV1.ResourcePods,
+}
+
+// ClusterStatusCollector는 주기적으로 cluster manager가 관리하는 cluster의 node 정보와
+// resource capacity, usage를 수집하여 cluster manager status에 기록한다.
+type ClusterStatusCollector struct {
+	client.Client
+	Log      logr.Logger
+	Interval time.Duration
+}
+
+func (c *ClusterStatusCollector) SetupWithManager(mgr ctrl.Manager) error {
+	if c.Interval <= 0 {
+		c.Interval = DefaultStatusCollectInterval
+	}
+	return mgr.Add(c)
+}
+
+// NeedLeaderElection은 leader인 manager에서만 수집하도록 한다.
+func (c *ClusterStatusCollector) NeedLeaderElection() bool {
+	return true
+}
+
+// Start는 manager가 종료될 때까지 Interval마다 status를 수집한다.
+func (c *ClusterStatusCollector) Start(ctx context.Context) error {
+	c.Log.Info("Start cluster status collector", "interval", c.Interval)
+	wait.UntilWithContext(ctx, c.collect, c.Interval)
+	return nil
+}
+
+func (c *ClusterStatusCollector) collect(ctx context.Context) {
+	clmList := &clusterV1alpha1.ClusterManagerList{}
+	if err := c.List(ctx, clmList); err != nil {
+		c.Log.Error(err, "Failed to list ClusterManagers")
+		return
+	}
+
+	for i := range clmList.Items {
+		clm := &clmList.Items[i]
+		if !clm.DeletionTimestamp.IsZero() || !clm.Status.ControlPlaneReady {
+			continue
+		}
+
+		collectCtx, cancel := context.WithTimeout(ctx, statusCollectTimeout)
+		if err := c.collectClusterStatus(collectCtx, clm); err != nil {
+			c.Log.Info("Failed to collect cluster status", "clustermanager", clm.GetNamespacedName(), "reason", err.Error())
+		}
+		cancel()
+	}
+}
+
+func (c *ClusterStatusCollector) collectClusterStatus(ctx context.Context, clusterManager *clusterV1alpha1.ClusterManager) error {
+	log := c.Log.WithValues("clustermanager", clusterManager.GetNamespacedName())
+
+	key := types.NamespacedName{
+		Name:      clusterManager.Name + util.KubeconfigSuffix,
+		Namespace: clusterManager.Namespace,
+	}
+	kubeconfigSecret := &coreV1.Secret{}
+	if err := c.Get(ctx, key, kubeconfigSecret); err != nil {
+		return err
+	}
+
+	remoteClientset, err := util.GetRemoteK8sClient(kubeconfigSecret)
+	if err != nil {
+		return err
+	}
+
+	nodeList, err := remoteClientset.
+		CoreV1().
+		Nodes().
+		List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	// metrics-server가 없는 cluster도 있으므로 usage 없이 capacity만 기록한다.
+	nodeMetrics, err := util.GetNodeMetrics(ctx, remoteClientset)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			log.V(4).Info("Failed to get node metrics", "reason", err.Error())
+		}
+		nodeMetrics = nil
+	}
+
+	before := clusterManager.DeepCopy()
+	nodeInfo, resources := buildNodeInfo(nodeList.Items, nodeMetrics)
+	clusterManager.Status.NodeInfo = nodeInfo
+	clusterManager.Status.Resources = resources
+
+	// 등록한 cluster는 machine을 watch할 수 없으므로 node 상태로 ready인 node 수를 갱신한다.
+	if clusterManager.GetClusterType() == clusterV1alpha1.ClusterTypeRegistered {
+		clusterManager.Status.MasterRun = 0
+		clusterManager.Status.WorkerRun = 0
+		for _, node := range nodeInfo {
+			if !node.Ready {
+				continue
+			}
+			if node.Role == nodeRoleMaster {
+				clusterManager.Status.MasterRun++
+			} else {
+				clusterManager.Status.WorkerRun++
+			}
+		}
+	}
+
+	now := metav1.Now()
+	clusterManager.Status.LastCollectedTime = &now
+	if err := c.Status().Patch(ctx, clusterManager, client.MergeFrom(before)); err != nil {
+		return err
+	}
+	return nil
+}
+
+const (
+	nodeRoleMaster = "master"
+	nodeRoleWorker = "worker"
+)
+
+// buildNodeInfo는 node 목록과 metrics로 node별 정보와 전체 resource 합계를 만든다.
+// metrics가 nil이면 usage는 기록하지 않는다.
+func buildNodeInfo(nodes []coreV1.Node, metrics map[string]coreV1.ResourceList) ([]clusterV1alpha1.NodeInfo, []clusterV1alpha1.ResourceType) {
+	nodeInfo := []clusterV1alpha1.NodeInfo{}
+	capacity := coreV1.ResourceList{}
+	allocatable := coreV1.ResourceList{}
+	usage := coreV1.ResourceList{}
+
+	for _, node := range nodes {
+		info := clusterV1alpha1.NodeInfo{
+			Name:           node.Name,
+			Role:           nodeRoleWorker,
+			KubeletVersion: node.Status.NodeInfo.KubeletVersion,
+			OsImage:        node.Status.NodeInfo.OSImage,
+		}
+		if _, ok := node.Labels[LabelKeyControlplaneNode]; ok {
+			info.Role = nodeRoleMaster
+		} else if _, ok := node.Labels[LabelKeyControlplaneNodeRole]; ok {
+			info.Role = nodeRoleMaster
+		}
+		for _, address := range node.Status.Addresses {
+			if address.Type == coreV1.NodeInternalIP {
+				info.Ip = address.Address
+				break
+			}
+		}
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == coreV1.NodeReady {
+				info.Ready = condition.Status == coreV1.ConditionTrue
+			}
+		}
+
+		nodeUsage, hasMetrics := metrics[node.Name]
+		for _, name := range collectedResources {
+			r := clusterV1alpha1.ResourceType{
+				Type:        string(name),
+				Capacity:    quantityString(node.Status.Capacity, name),
+				Allocatable: quantityString(node.Status.Allocatable, name),
+			}
+			addQuantity(capacity, node.Status.Capacity, name)
+			addQuantity(allocatable, node.Status.Allocatable, name)
+			if hasMetrics {
+				r.Usage = quantityString(nodeUsage, name)
+				addQuantity(usage, nodeUsage, name)
+			}
+			info.Resources = append(info.Resources, r)
+		}
+		nodeInfo = append(nodeInfo, info)
+	}
+
+	resources := []clusterV1alpha1.ResourceType{}
+	for _, name := range collectedResources {
+		resources = append(resources, clusterV1alpha1.ResourceType{
+			Type:        string(name),
+			Capacity:    quantityString(capacity, name),
+			Allocatable: quantityString(allocatable, name),
+			Usage:       quantityString(usage, name),
+		})
+	}
+	return nodeInfo, resources
+}
+
+func quantityString(list coreV1.ResourceList, name coreV1.ResourceName) string {
+	if q, ok := list[name]; ok {
+		return q.String()
+	}
+	return ""
+}
+
+func addQuantity(dest, src coreV1.ResourceList, name coreV1.ResourceName) {
+	q, ok := src[name]
+	if !ok {
+		return
+	}
+	sum, ok := dest[name]
+	if !ok {
+		sum = resource.Quantity{}
+	}
+	sum.Add(q)
+	dest[name] = sum
+}
+
+// isPeriodicStatusUpdate는 status collector, health prober가 주기적으로 기록하는 값만 변경되었는지 확인한다.
+// 수집할 때마다 reconcile이 수행되지 않도록 predicate에서 사용한다.
+func isPeriodicStatusUpdate(oldclm, newclm *clusterV1alpha1.ClusterManager) bool {
+	clearCollected := func(clm *clusterV1alpha1.ClusterManager) *clusterV1alpha1.ClusterManager {
+		c := clm.DeepCopy()
+		c.Status.NodeInfo = nil
+		c.Status.Resources = nil
+		c.Status.LastCollectedTime = nil
+		c.Status.LastContactTime = nil
+		c.Status.MasterRun = 0
+		c.Status.WorkerRun = 0
+		return c
+	}
+	if oldclm.Status.LastCollectedTime.Equal(newclm.Status.LastCollectedTime) &&
+		oldclm.Status.LastContactTime.Equal(newclm.Status.LastContactTime) {
+		return false
+	}
+	o, n := clearCollected(oldclm), clearCollected(newclm)
+	return reflect.DeepEqual(o.Spec, n.Spec) &&
+		reflect.DeepEqual(o.Status, n.Status) &&
+		reflect.DeepEqual(o.Labels, n.Labels) &&
+		reflect.DeepEqual(o.Annotations, n.Annotations) &&
+		reflect.DeepEqual(o.Finalizers, n.Finalizers) &&
+		o.DeletionTimestamp.Equal(n.DeletionTimestamp)
+}
@@ -1,101 +1,93 @@
 package controllers
 
 import (
+	"sort"
 	"strings"
 
 	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/provider"
 	util "github.com/tmax-cloud/hypercloud-multi-operator/controllers/util"
 	tmaxv1 "github.com/tmax-cloud/template-operator/api/v1"
 
+	coreV1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func buildClusterParams(clm clusterV1alpha1.ClusterManager) []tmaxv1.ParamSpec {
 	params := []tmaxv1.ParamSpec{
-		buildParam(CLUSTER_PARAM_NAMESPACE, clm.Namespace, intstr.String),
-		buildParam(CLUSTER_PARAM_CLUSTER_NAME, clm.Name, intstr.String),
-		buildParam(CLUSTER_PARAM_MASTER_NUM, clm.Spec.MasterNum, intstr.Int),
-		buildParam(CLUSTER_PARAM_WORKER_NUM, clm.Spec.WorkerNum, intstr.Int),
-		buildParam(CLUSTER_PARAM_OWNER, clm.Annotations[util.AnnotationKeyOwner], intstr.String),
-		buildParam(CLUSTER_PARAM_KUBERNETES_VERSION, clm.Spec.Version, intstr.String),
+		provider.BuildParam(CLUSTER_PARAM_NAMESPACE, clm.Namespace, intstr.String),
+		provider.BuildParam(CLUSTER_PARAM_CLUSTER_NAME, clm.Name, intstr.String),
+		provider.BuildParam(CLUSTER_PARAM_MASTER_NUM, clm.Spec.MasterNum, intstr.Int),
+		provider.BuildParam(CLUSTER_PARAM_WORKER_NUM, clm.Spec.WorkerNum, intstr.Int),
+		provider.BuildParam(CLUSTER_PARAM_OWNER, clm.Annotations[util.AnnotationKeyOwner], intstr.String),
+		provider.BuildParam(CLUSTER_PARAM_KUBERNETES_VERSION, clm.Spec.Version, intstr.String),
 	}
 
 	return params
 }
 
-func buildAwsParams(spec clusterV1alpha1.ProviderAwsSpec) []tmaxv1.ParamSpec {
+// buildWorkerPoolParams는 worker pool의 machinedeployment를 생성하기 위한 parameter를 만든다.
+// provider별 VM 사양은 provider에서 만든다.
+func buildWorkerPoolParams(p provider.Provider, clm clusterV1alpha1.ClusterManager, pool clusterV1alpha1.WorkerPool) []tmaxv1.ParamSpec {
 	params := []tmaxv1.ParamSpec{
-		buildParam(AWS_PARAM_AWS_SSH_KEY_NAME, spec.SshKey, intstr.String),
-		buildParam(AWS_PARAM_AWS_REGION, spec.Region, intstr.String),
-		buildParam(AWS_PARAM_AWS_CONTROL_PLANE_MACHINE_TYPE, spec.MasterType, intstr.String),
-		buildParam(AWS_PARAM_AWS_NODE_MACHINE_TYPE, spec.WorkerType, intstr.String),
-		buildParam(AWS_PARAM_MASTER_DISK_SIZE, spec.MasterDiskSize, intstr.Int),
-		buildParam(AWS_PARAM_WORKER_DISK_SIZE, spec.WorkerDiskSize, intstr.Int),
+		provider.BuildParam(CLUSTER_PARAM_NAMESPACE, clm.Namespace, intstr.String),
+		provider.BuildParam(CLUSTER_PARAM_CLUSTER_NAME, clm.Name, intstr.String),
+		provider.BuildParam(CLUSTER_PARAM_KUBERNETES_VERSION, clm.Spec.Version, intstr.String),
+		provider.BuildParam(CLUSTER_PARAM_WORKER_NUM, pool.Replicas, intstr.Int),
+		provider.BuildParam(WORKERPOOL_PARAM_POOL_NAME, pool.Name, intstr.String),
+		provider.BuildParam(WORKERPOOL_PARAM_NODE_LABELS, buildNodeLabels(pool.Labels), intstr.String),
+		provider.BuildParam(WORKERPOOL_PARAM_NODE_TAINTS, buildNodeTaints(pool.Taints), intstr.String),
 	}
 
-	return params
+	return mergeParams(params, p.WorkerPoolParams(&clm, pool))
 }
 
-func buildVsphereParams(spec clusterV1alpha1.ProviderVsphereSpec) []tmaxv1.ParamSpec {
-	params := []tmaxv1.ParamSpec{
-		buildParam(VSPHERE_PARAM_POD_CIDR, spec.PodCidr, intstr.String),
-		buildParam(VSPHERE_PARAM_VSPHERE_SERVER, spec.VcenterIp, intstr.String),
-		buildParam(VSPHERE_PARAM_VSPHERE_USERNAME, spec.VcenterId, intstr.String),
-		buildParam(VSPHERE_PARAM_VSPHERE_PASSWORD, spec.VcenterPassword, intstr.String),
-		buildParam(VSPHERE_PARAM_VSPHERE_TLS_THUMBPRINT, spec.VcenterThumbprint, intstr.String),
-		buildParam(VSPHERE_PARAM_VSPHERE_NETWORK, spec.VcenterNetwork, intstr.String),
-		buildParam(VSPHERE_PARAM_VSPHERE_DATACENTER, spec.VcenterDataCenter, intstr.String),
-		buildParam(VSPHERE_PARAM_VSPHERE_DATASTORE, spec.VcenterDataStore, intstr.String),
-		buildParam(VSPHERE_PARAM_VSPHERE_FOLDER, spec.VcenterFolder, intstr.String),
-		buildParam(VSPHERE_PARAM_VSPHERE_RESOURCE_POOL, spec.VcenterResourcePool, intstr.String),
-		buildParam(VSPHERE_PARAM_CONTROL_PLANE_ENDPOINT_IP, spec.VcenterKcpIp, intstr.String),
-		buildParam(VSPHERE_PARAM_MASTER_CPU_NUM, spec.VcenterCpuNum, intstr.Int),
-		buildParam(VSPHERE_PARAM_MASTER_MEM_SIZE, spec.VcenterMemSize, intstr.Int),
-		buildParam(VSPHERE_PARAM_MASTER_DISK_SIZE, spec.VcenterDiskSize, intstr.Int),
-		buildParam(VSPHERE_PARAM_WORKER_CPU_NUM, spec.VcenterCpuNum, intstr.Int),
-		buildParam(VSPHERE_PARAM_WORKER_MEM_SIZE, spec.VcenterMemSize, intstr.Int),
-		buildParam(VSPHERE_PARAM_WORKER_DISK_SIZE, spec.VcenterDiskSize, intstr.Int),
-		buildParam(VSPHERE_PARAM_VSPHERE_TEMPLATE, spec.VcenterTemplate, intstr.String),
-		buildParam(VSPHERE_PARAM_VM_PASSWORD, spec.VMPassword, intstr.String),
+// buildNodeLabels는 kubelet의 --node-labels 형식(k1=v1,k2=v2)으로 변환한다.
+func buildNodeLabels(labels map[string]string) string {
+	list := []string{}
+	for k, v := range labels {
+		list = append(list, k+"="+v)
 	}
-	return params
+	sort.Strings(list)
+	return strings.Join(list, ",")
 }
 
-func buildVsphereUpgradeParams(clm clusterV1alpha1.ClusterManager) []tmaxv1.ParamSpec {
-	params := []tmaxv1.ParamSpec{
-		buildParam(VSPHERE_UPGRADE_PARAM_NAMESPACE, clm.Namespace, intstr.String),
-		buildParam(VSPHERE_UPGRADE_PARAM_VSPHERE_SERVER, clm.VsphereSpec.VcenterIp, intstr.String),
-		buildParam(VSPHERE_UPGRADE_PARAM_VSPHERE_TLS_THUMBPRINT, clm.VsphereSpec.VcenterThumbprint, intstr.String),
-		buildParam(VSPHERE_UPGRADE_PARAM_VSPHERE_NETWORK, clm.VsphereSpec.VcenterNetwork, intstr.String),
-		buildParam(VSPHERE_UPGRADE_PARAM_VSPHERE_DATACENTER, clm.VsphereSpec.VcenterDataCenter, intstr.String),
-		buildParam(VSPHERE_UPGRADE_PARAM_VSPHERE_DATASTORE, clm.VsphereSpec.VcenterDataStore, intstr.String),
-		buildParam(VSPHERE_UPGRADE_PARAM_VSPHERE_FOLDER, clm.VsphereSpec.VcenterFolder, intstr.String),
-		buildParam(VSPHERE_UPGRADE_PARAM_VSPHERE_RESOURCE_POOL, clm.VsphereSpec.VcenterResourcePool, intstr.String),
-		buildParam(VSPHERE_UPGRADE_PARAM_VSPHERE_TEMPLATE, clm.VsphereSpec.VcenterTemplate, intstr.String),
-		buildParam(VSPHERE_UPGRADE_PARAM_CPU_NUM, clm.VsphereSpec.VcenterCpuNum, intstr.Int),
-		buildParam(VSPHERE_UPGRADE_PARAM_MEM_SIZE, clm.VsphereSpec.VcenterMemSize, intstr.Int),
-		buildParam(VSPHERE_UPGRADE_PARAM_DISK_SIZE, clm.VsphereSpec.VcenterDiskSize, intstr.Int),
-		buildParam(VSPHERE_UPGRADE_PARAM_KUBERNETES_VERSION, clm.Spec.Version, intstr.String),
-		buildParam(VSPHERE_UPGRADE_PARAM_VSPHERE_TEMPLATE, clm.VsphereSpec.VcenterTemplate, intstr.String),
+// buildNodeTaints는 kubelet의 --register-with-taints 형식(k1=v1:Effect,k2:Effect)으로 변환한다.
+func buildNodeTaints(taints []coreV1.Taint) string {
+	list := []string{}
+	for _, taint := range taints {
+		list = append(list, taint.ToString())
 	}
-	return params
+	return strings.Join(list, ",")
+}
+
+func getClusterTemplateName(clusterManager *clusterV1alpha1.ClusterManager) string {
+	return "capi-" + strings.ToLower(clusterManager.Spec.Provider) + "-template"
+}
+
+func getWorkerPoolTemplateName(clusterManager *clusterV1alpha1.ClusterManager) string {
+	return "capi-" + strings.ToLower(clusterManager.Spec.Provider) + "-workerpool-template"
+}
+
+// BuildClusterTemplateInstance는 cluster를 생성하는 template instance를 만든다.
+// claim의 dry run에서도 같은 parameter를 보여주기 위해 사용한다.
+func BuildClusterTemplateInstance(p provider.Provider, clusterManager *clusterV1alpha1.ClusterManager, instanceName string) (*tmaxv1.TemplateInstance, error) {
+	params := mergeParams(buildClusterParams(*clusterManager), p.ClusterParams(clusterManager))
+	return ConstructTemplateInstance(clusterManager, instanceName, getClusterTemplateName(clusterManager), params)
+}
+
+// BuildWorkerPoolTemplateInstance는 worker pool의 machinedeployment를 생성하는 template instance를 만든다.
+func BuildWorkerPoolTemplateInstance(p provider.Provider, clusterManager *clusterV1alpha1.ClusterManager, pool clusterV1alpha1.WorkerPool) (*tmaxv1.TemplateInstance, error) {
+	params := buildWorkerPoolParams(p, *clusterManager, pool)
+	return ConstructTemplateInstance(clusterManager, clusterManager.GetMachineDeploymentName(pool.Name), getWorkerPoolTemplateName(clusterManager), params)
 }
 
 // ConstructTemplateInstance는 clusterManager를 이용하여 templateInstance를 생성한다.
 func ConstructTemplateInstance(clusterManager *clusterV1alpha1.ClusterManager,
 	templateInstanceName string,
-	parameters []tmaxv1.ParamSpec,
-	upgrade bool) (*tmaxv1.TemplateInstance, error) {
-	var templateName string
-
-	if upgrade {
-		// vsphere upgrade에 대해서만 templateinstance를 생성하므로
-		templateName = CAPI_VSPHERE_UPGRADE_TEMPLATE
-	} else {
-		templateName = "capi-" + strings.ToLower(clusterManager.Spec.Provider) + "-template"
-	}
-
+	templateName string,
+	parameters []tmaxv1.ParamSpec) (*tmaxv1.TemplateInstance, error) {
 	annotations := map[string]string{
 		util.AnnotationKeyOwner:   clusterManager.Annotations[util.AnnotationKeyCreator],
 		util.AnnotationKeyCreator: clusterManager.Annotations[util.AnnotationKeyCreator],
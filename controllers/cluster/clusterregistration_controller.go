@@ -16,15 +16,19 @@ package controllers
 
 import (
 	"context"
+	"strings"
 
 	"github.com/go-logr/logr"
 	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/metrics"
 	util "github.com/tmax-cloud/hypercloud-multi-operator/controllers/util"
 
+	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
 
 	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -38,8 +42,9 @@ import (
 // ClusterRegistrationReconciler reconciles a ClusterRegistration object
 type ClusterRegistrationReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=cluster.tmax.io,resources=clusterregistrations,verbs=create;delete;get;list;patch;update;watch
@@ -64,6 +69,8 @@ func (r *ClusterRegistrationReconciler) Reconcile(ctx context.Context, req ctrl.
 		return ctrl.Result{}, err
 	}
 
+	before := clusterRegistration.Status.DeepCopy()
+
 	defer func() {
 		// Always reconcile the Status.Phase field.
 		r.reconcilePhase(context.TODO(), clusterRegistration)
@@ -72,6 +79,8 @@ func (r *ClusterRegistrationReconciler) Reconcile(ctx context.Context, req ctrl.
 			// if err := patchClusterRegistration(context.TODO(), patchHelper, ClusterRegistration, patchOpts...); err != nil {
 			// reterr = kerrors.NewAggregate([]error{reterr, err})
 			reterr = err
+		} else {
+			r.recordStatusEvent(before, clusterRegistration)
 		}
 	}()
 
@@ -98,6 +107,7 @@ func (r *ClusterRegistrationReconciler) reconcile(ctx context.Context, ClusterRe
 		// Call the inner reconciliation methods.
 		phaseResult, err := phase(ctx, ClusterRegistration)
 		if err != nil {
+			metrics.PhaseErrors.WithLabelValues("clusterregistration", metrics.FuncName(phase)).Inc()
 			errs = append(errs, err)
 		}
 		if len(errs) > 0 {
@@ -111,6 +121,21 @@ func (r *ClusterRegistrationReconciler) reconcile(ctx context.Context, ClusterRe
 	return res, kerrors.NewAggregate(errs)
 }
 
+// recordStatusEvent는 phase 또는 reason이 바뀐 경우 event를 남긴다.
+func (r *ClusterRegistrationReconciler) recordStatusEvent(before *clusterV1alpha1.ClusterRegistrationStatus, clusterRegistration *clusterV1alpha1.ClusterRegistration) {
+	status := clusterRegistration.Status
+	if status.Phase == "" || (before.Phase == status.Phase && before.Reason == status.Reason) {
+		return
+	}
+
+	if status.Phase == clusterV1alpha1.ClusterRegistrationPhaseError {
+		r.Recorder.Eventf(clusterRegistration, coreV1.EventTypeWarning, "Error", "Failed to register cluster: %s", status.Reason)
+		return
+	}
+	r.Recorder.Eventf(clusterRegistration, coreV1.EventTypeNormal, strings.ReplaceAll(string(status.Phase), " ", ""),
+		"Phase changed to %s", status.Phase)
+}
+
 func (r *ClusterRegistrationReconciler) reconcilePhase(_ context.Context, ClusterRegistration *clusterV1alpha1.ClusterRegistration) {
 	if ClusterRegistration.Status.ClusterValidated {
 		ClusterRegistration.Status.SetTypedPhase(clusterV1alpha1.ClusterRegistrationPhaseRegistered)
@@ -146,6 +171,7 @@ func (r *ClusterRegistrationReconciler) requeueClusterRegistrationsForClusterMan
 		log.Error(err, "Failed to update ClusterRegistration status")
 		return nil //??
 	}
+	r.Recorder.Event(clr, coreV1.EventTypeNormal, "ClusterDeleted", string(clr.Status.Reason))
 	return nil
 }
 
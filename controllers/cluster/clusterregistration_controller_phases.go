@@ -188,6 +188,7 @@ func (r *ClusterRegistrationReconciler) CreateClusterManager(ctx context.Context
 			log.Error(err, "Failed to create ClusterManager for ["+clusterRegistration.Spec.ClusterName+"]")
 			return ctrl.Result{}, err
 		}
+		r.Recorder.Eventf(clusterRegistration, coreV1.EventTypeNormal, "ClusterManagerCreated", "ClusterManager %s is created", clm.Name)
 	} else if err != nil {
 		log.Error(err, "Failed to get ClusterManager")
 		return ctrl.Result{}, err
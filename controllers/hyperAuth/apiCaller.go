@@ -55,7 +55,7 @@ func GetTokenAsAdmin(secret *coreV1.Secret) (string, error) {
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
 	// Request with Client Object
-	client := &http.Client{}
+	client := newHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return "", err
@@ -88,7 +88,7 @@ func GetIdByClientId(clientId string, secret *coreV1.Secret) (string, error) {
 	}
 	req.Header.Add("Authorization", token)
 
-	client := &http.Client{}
+	client := newHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return "", err
@@ -135,7 +135,7 @@ func CreateClient(config ClientConfig, secret *coreV1.Secret) error {
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Authorization", token)
 
-	client := &http.Client{}
+	client := newHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -178,7 +178,7 @@ func CreateClientLevelProtocolMapper(config ClientLevelProtocolMapperConfig, sec
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Authorization", token)
 
-	client := &http.Client{}
+	client := newHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -224,7 +224,7 @@ func CreateClientLevelRole(config ClientLevelRoleConfig, secret *coreV1.Secret)
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Authorization", token)
 
-	client := &http.Client{}
+	client := newHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -256,7 +256,7 @@ func GetUserIdByEmail(userEmail string, secret *coreV1.Secret) (string, error) {
 	req.Header.Add("Authorization", token)
 	req.Header.Add("Content-Type", "application/json")
 
-	client := &http.Client{}
+	client := newHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return "", err
@@ -303,7 +303,7 @@ func GetClientRoleIdByRoleName(clientId string, roleName string, secret *coreV1.
 	}
 	req.Header.Add("Authorization", token)
 
-	client := &http.Client{}
+	client := newHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return "", err
@@ -371,7 +371,7 @@ func AddClientLevelRolesToUserRoleMapping(config ClientLevelRoleConfig, userEmai
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Authorization", token)
 
-	client := &http.Client{}
+	client := newHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -402,7 +402,7 @@ func GetRealmRoleIdByRoleName(roleName string, secret *coreV1.Secret) (string, e
 	}
 	req.Header.Add("Authorization", token)
 
-	client := &http.Client{}
+	client := newHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return "", err
@@ -464,7 +464,7 @@ func AddRealmLevelRolesToUserRoleMapping(roleName string, userEmail string, secr
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Authorization", token)
 
-	client := &http.Client{}
+	client := newHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -492,7 +492,7 @@ func GetClientScopesIdByName(name string, secret *coreV1.Secret) (string, error)
 	}
 	req.Header.Add("Authorization", token)
 
-	client := &http.Client{}
+	client := newHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return "", err
@@ -548,7 +548,7 @@ func AddClientScopeToClient(config ClientScopeMappingConfig, secret *coreV1.Secr
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Authorization", token)
 
-	client := &http.Client{}
+	client := newHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -584,7 +584,7 @@ func CreateGroup(config GroupConfig, secret *coreV1.Secret) error {
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Authorization", token)
 
-	client := &http.Client{}
+	client := newHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -612,7 +612,7 @@ func GetGroupIdByName(name string, secret *coreV1.Secret) (string, error) {
 	}
 	req.Header.Add("Authorization", token)
 
-	client := &http.Client{}
+	client := newHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return "", err
@@ -666,7 +666,7 @@ func AddGroupToUser(userEmail string, config GroupConfig, secret *coreV1.Secret)
 	}
 	req.Header.Add("Authorization", token)
 
-	client := &http.Client{}
+	client := newHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -704,7 +704,7 @@ func DeleteClient(config ClientConfig, secret *coreV1.Secret) error {
 	}
 	req.Header.Add("Authorization", token)
 
-	client := &http.Client{}
+	client := newHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -742,7 +742,7 @@ func DeleteGroup(config GroupConfig, secret *coreV1.Secret) error {
 	}
 	req.Header.Add("Authorization", token)
 
-	client := &http.Client{}
+	client := newHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
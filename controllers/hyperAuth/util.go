@@ -16,8 +16,15 @@ package hyperAuth
 
 import (
 	"net/http"
+
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/metrics"
 )
 
+// HyperAuth 호출의 latency와 실패를 metric으로 기록하는 client
+func newHttpClient() *http.Client {
+	return &http.Client{Transport: metrics.InstrumentRoundTripper(metrics.ServiceHyperAuth, nil)}
+}
+
 func IsOK(check int) bool {
 	SuccessStatusList := map[int]bool{
 		http.StatusOK:             true,
@@ -17,9 +17,11 @@ package controllers
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/metrics"
 	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/util"
 
 	coreV1 "k8s.io/api/core/v1"
@@ -27,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
 
 	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -39,11 +42,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
+// paused인 cluster manager의 resume 여부를 다시 확인하는 주기
+const requeueAfterPaused = 1 * time.Minute
+
 // ClusterReconciler reconciles a Memcached object
 type SecretReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups="",resources=secrets;namespaces;serviceaccounts,verbs=create;delete;get;list;patch;post;update;watch;
@@ -94,6 +101,21 @@ func (r *SecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ c
 		secret.Labels[clusterV1alpha1.LabelKeyClmNamespace] = secret.Namespace
 	}
 
+	// detach된 cluster의 kubeconfig secret은 cluster manager가 없으므로 처리하지 않는다.
+	if _, ok := secret.Annotations[clusterV1alpha1.AnnotationKeyClmDetached]; ok {
+		log.Info("Cluster is detached from ClusterManager. Skip reconciliation")
+		return ctrl.Result{}, nil
+	}
+
+	// paused인 cluster manager의 secret은 resume될 때까지 처리하지 않고 주기적으로 다시 확인한다.
+	if paused, err := r.isClusterManagerPaused(secret); err != nil {
+		log.Error(err, "Failed to get ClusterManager")
+		return ctrl.Result{}, err
+	} else if paused {
+		log.Info("ClusterManager is paused. Skip reconciliation")
+		return ctrl.Result{RequeueAfter: requeueAfterPaused}, nil
+	}
+
 	// sjoh
 	// _, isCapiKubeconfig := secret.Labels[util.LabelKeyCapiClusterName]
 	// Add finalizer first if not exist to avoid the race condition between init and delete
@@ -134,6 +156,7 @@ func (r *SecretReconciler) reconcile(ctx context.Context, secret *coreV1.Secret)
 		// Call the inner reconciliation methods.
 		phaseResult, err := phase(ctx, secret)
 		if err != nil {
+			metrics.PhaseErrors.WithLabelValues("secret", metrics.FuncName(phase)).Inc()
 			errs = append(errs, err)
 		}
 		if len(errs) > 0 {
@@ -254,6 +277,9 @@ func (r *SecretReconciler) reconcileDelete(ctx context.Context, secret *coreV1.S
 		} else {
 			log.Info("Deleted ClusterRole from remote cluster successfully")
 		}
+		r.Recorder.Event(clm, coreV1.EventTypeNormal, "RemoteRBACDeleted", "Deleted RBAC resources from remote cluster")
+	} else {
+		r.Recorder.Event(clm, coreV1.EventTypeWarning, "RemoteClusterUnhealthy", "Remote cluster is not healthy. Skip deleting RBAC resources from remote cluster")
 	}
 
 	// master cluster에 있는 리소스 삭제
@@ -76,7 +76,7 @@ func (r *SecretReconciler) UpdateClusterManagerControlPlaneEndpoint(ctx context.
 	return ctrl.Result{}, nil
 }
 
-func (r *SecretReconciler) DeployRBACResources(ctx context.Context, secret *coreV1.Secret) (ctrl.Result, error) {
+func (r *SecretReconciler) DeployRBACResources(ctx context.Context, secret *coreV1.Secret) (_ ctrl.Result, reterr error) {
 	log := r.Log.WithValues(
 		"secret",
 		types.NamespacedName{
@@ -96,6 +96,16 @@ func (r *SecretReconciler) DeployRBACResources(ctx context.Context, secret *core
 		return ctrl.Result{}, err
 	}
 
+	// 매 reconcile마다 수행되므로 새로 생성한 리소스가 있거나 실패한 경우에만 event를 남긴다.
+	created := []string{}
+	defer func() {
+		if reterr != nil {
+			r.Recorder.Eventf(clm, coreV1.EventTypeWarning, "RemoteRBACFailed", "Failed to deploy RBAC resources to remote cluster: %v", reterr)
+		} else if len(created) > 0 {
+			r.Recorder.Eventf(clm, coreV1.EventTypeNormal, "RemoteRBACDeployed", "Created %s in remote cluster", strings.Join(created, ", "))
+		}
+	}()
+
 	remoteClientset, err := util.GetRemoteK8sClient(secret)
 	if err != nil {
 		log.Error(err, "Failed to get remoteK8sClient")
@@ -134,6 +144,7 @@ func (r *SecretReconciler) DeployRBACResources(ctx context.Context, secret *core
 			return ctrl.Result{}, err
 		}
 		log.Info("Create ClusterRoleBinding for cluster-admin to remote cluster successfully")
+		created = append(created, "ClusterRoleBinding/"+clusterAdminCRB.Name)
 	} else if err != nil {
 		log.Error(err, "Failed to get ClusterRoleBinding for cluster-admin from remote cluster")
 		return ctrl.Result{}, err
@@ -171,6 +182,7 @@ func (r *SecretReconciler) DeployRBACResources(ctx context.Context, secret *core
 				return ctrl.Result{}, err
 			}
 			log.Info("Create ClusterRole [" + targetCr.Name + "] to remote cluster successfully")
+			created = append(created, "ClusterRole/"+targetCr.Name)
 		} else if err != nil {
 			log.Error(err, "Failed to get ClusterRole ["+targetCr.Name+"] from remote cluster")
 			return ctrl.Result{}, err
@@ -199,6 +211,7 @@ func (r *SecretReconciler) DeployRBACResources(ctx context.Context, secret *core
 			return ctrl.Result{}, err
 		}
 		log.Info("Create ServiceAccount [" + adminServiceAccount.Name + "] to remote cluster successfully")
+		created = append(created, "ServiceAccount/"+adminServiceAccount.Name)
 	} else if err != nil {
 		log.Error(err, "Failed to get ServiceAccount ["+adminServiceAccount.Name+"] from remote cluster")
 		return ctrl.Result{}, err
@@ -227,6 +240,7 @@ func (r *SecretReconciler) DeployRBACResources(ctx context.Context, secret *core
 			return ctrl.Result{}, err
 		}
 		log.Info("Create ServiceAccount token secret [" + adminServiceAccount.Name + "] to remote cluster successfully")
+		created = append(created, "Secret/"+adminServiceAccountTokenSecret.Name)
 	} else if err != nil {
 		log.Error(err, "Failed to get ServiceAccount token secret ["+adminServiceAccount.Name+"] from remote cluster")
 		return ctrl.Result{}, err
@@ -263,6 +277,7 @@ func (r *SecretReconciler) DeployRBACResources(ctx context.Context, secret *core
 			return ctrl.Result{}, err
 		}
 		log.Info("Create ClusterRoleBinding for admin service account to remote cluster successfully")
+		created = append(created, "ClusterRoleBinding/"+adminServiceAccountCRB.Name)
 	} else if err != nil {
 		log.Error(err, "Failed to get ClusterRoleBinding for admin service account from remote cluster")
 		return ctrl.Result{}, err
@@ -24,6 +24,7 @@ import (
 	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
 
 	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/util"
+	coreV1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -225,3 +226,22 @@ func FetchMemberList(clusterManager clusterV1alpha1.ClusterManager) ([]ClusterMe
 	}
 	return memberList, nil
 }
+
+// isClusterManagerPaused는 secret의 label에 기록된 cluster manager가 paused인지 확인한다.
+func (r *SecretReconciler) isClusterManagerPaused(secret *coreV1.Secret) (bool, error) {
+	key := types.NamespacedName{
+		Name:      secret.Labels[clusterV1alpha1.LabelKeyClmName],
+		Namespace: secret.Labels[clusterV1alpha1.LabelKeyClmNamespace],
+	}
+	if key.Name == "" || key.Namespace == "" {
+		return false, nil
+	}
+
+	clm := &clusterV1alpha1.ClusterManager{}
+	if err := r.Client.Get(context.TODO(), key, clm); errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return clm.Spec.Paused, nil
+}
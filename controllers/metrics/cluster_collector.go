@@ -0,0 +1,67 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// informer cache를 조회하므로 짧게 둔다.
+const clusterCollectTimeout = 5 * time.Second
+
+var clustersDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(metricNamespace, "", "clusters"),
+	"Number of clusters by phase, provider and type.",
+	[]string{"phase", "provider", "type"}, nil,
+)
+
+// ClusterCollector는 scrape할 때마다 cluster manager를 조회하여 phase, provider, type별 cluster 수를 노출한다.
+// 삭제된 cluster의 gauge가 남지 않도록 gauge를 직접 갱신하지 않고 조회 결과로 metric을 생성한다.
+type ClusterCollector struct {
+	Client client.Reader
+	Log    logr.Logger
+}
+
+var _ prometheus.Collector = &ClusterCollector{}
+
+func (c *ClusterCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- clustersDesc
+}
+
+func (c *ClusterCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), clusterCollectTimeout)
+	defer cancel()
+
+	clmList := &clusterV1alpha1.ClusterManagerList{}
+	if err := c.Client.List(ctx, clmList); err != nil {
+		c.Log.Error(err, "Failed to list ClusterManagers")
+		return
+	}
+
+	counts := map[[3]string]int{}
+	for _, clm := range clmList.Items {
+		key := [3]string{string(clm.Status.GetTypedPhase()), clm.Spec.Provider, clm.GetClusterType()}
+		counts[key]++
+	}
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(clustersDesc, prometheus.GaugeValue, float64(count), key[0], key[1], key[2])
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net/http"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricNamespace = "hypercloud_multi_operator"
+
+// 외부 API 호출 metric의 service label
+const (
+	ServiceHyperAuth     = "hyperauth"
+	ServiceHypercloudApi = "hypercloud-api"
+	ServiceHyperregistry = "hyperregistry"
+)
+
+var (
+	// PhaseDuration은 cluster가 각 단계(provisioning, argocd, gateway, auth, traefik, upgrade, scaling)를
+	// 완료하는 데 걸린 시간
+	PhaseDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricNamespace,
+			Name:      "cluster_phase_duration_seconds",
+			Help:      "Time spent by a cluster to complete each phase.",
+			// 30s ~ 약 4시간
+			Buckets: prometheus.ExponentialBuckets(30, 2, 10),
+		},
+		[]string{"phase", "provider", "type"},
+	)
+
+	// PhaseErrors는 reconcile phase 함수가 반환한 error의 수
+	PhaseErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricNamespace,
+			Name:      "reconcile_phase_errors_total",
+			Help:      "Number of errors returned by each reconcile phase function.",
+		},
+		[]string{"controller", "phase"},
+	)
+
+	// ExternalRequestDuration은 HyperAuth, HyperCloud API, Hyperregistry 호출의 latency
+	ExternalRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricNamespace,
+			Name:      "external_request_duration_seconds",
+			Help:      "Latency of requests to external services.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"service", "method", "code"},
+	)
+
+	// ExternalRequestFailures는 연결 실패 또는 5xx 응답을 받은 외부 API 호출의 수
+	// HyperAuth는 이미 존재하는 resource에 대해 409를 반환하는 등 4xx도 정상 처리하는 경우가 있으므로
+	// 4xx는 ExternalRequestDuration의 code label로 확인한다.
+	ExternalRequestFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricNamespace,
+			Name:      "external_request_failures_total",
+			Help:      "Number of failed requests to external services.",
+		},
+		[]string{"service", "method"},
+	)
+)
+
+func init() {
+	// manager의 metrics registry에 등록하면 --metrics-addr로 노출된다.
+	ctrlmetrics.Registry.MustRegister(
+		PhaseDuration,
+		PhaseErrors,
+		ExternalRequestDuration,
+		ExternalRequestFailures,
+	)
+}
+
+// FuncName은 phase 함수의 이름을 metric label로 사용하기 위해 반환한다.
+// method value의 경우 "CreateArgocdResources"와 같이 method 이름만 반환한다.
+func FuncName(f interface{}) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(f).Pointer())
+	if fn == nil {
+		return "unknown"
+	}
+	name := strings.TrimSuffix(fn.Name(), "-fm")
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// InstrumentRoundTripper는 요청의 latency와 실패를 service label로 기록하는 RoundTripper를 반환한다.
+// next가 nil이면 http.DefaultTransport를 사용한다.
+func InstrumentRoundTripper(service string, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next.RoundTrip(req)
+
+		code := "error"
+		if err == nil {
+			code = strconv.Itoa(resp.StatusCode)
+		}
+		ExternalRequestDuration.WithLabelValues(service, req.Method, code).Observe(time.Since(start).Seconds())
+		if err != nil || resp.StatusCode >= http.StatusInternalServerError {
+			ExternalRequestFailures.WithLabelValues(service, req.Method).Inc()
+		}
+		return resp, err
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,127 @@
+package aws
+
+import (
+	"context"
+
+	claimV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/claim/v1alpha1"
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/provider"
+	tmaxv1 "github.com/tmax-cloud/template-operator/api/v1"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Aws Parameter
+	AWS_PARAM_AWS_SSH_KEY_NAME               = "AWS_SSH_KEY_NAME"
+	AWS_PARAM_AWS_REGION                     = "AWS_REGION"
+	AWS_PARAM_AWS_CONTROL_PLANE_MACHINE_TYPE = "AWS_CONTROL_PLANE_MACHINE_TYPE"
+	AWS_PARAM_AWS_NODE_MACHINE_TYPE          = "AWS_NODE_MACHINE_TYPE"
+	AWS_PARAM_MASTER_DISK_SIZE               = "MASTER_DISK_SIZE"
+	AWS_PARAM_WORKER_DISK_SIZE               = "WORKER_DISK_SIZE"
+)
+
+func init() {
+	provider.Register(&awsProvider{})
+}
+
+type awsProvider struct{}
+
+func (p *awsProvider) Name() string {
+	return clusterV1alpha1.ProviderAWS
+}
+
+func (p *awsProvider) ValidateClaim(cc *claimV1alpha1.ClusterClaim) field.ErrorList {
+	return nil
+}
+
+// aws spec configuration
+func (p *awsProvider) ConvertClaim(cc *claimV1alpha1.ClusterClaim, clm *clusterV1alpha1.ClusterManager) error {
+	region := cc.Spec.ProviderAwsSpec.Region
+	if region == "" {
+		region = "ap-northeast-2"
+	}
+
+	masterType := cc.Spec.ProviderAwsSpec.MasterType
+	if masterType == "" {
+		masterType = "t3.medium"
+	}
+
+	workerType := cc.Spec.ProviderAwsSpec.WorkerType
+	if workerType == "" {
+		workerType = "t3.medium"
+	}
+
+	masterDiskSize := cc.Spec.ProviderAwsSpec.MasterDiskSize
+	if masterDiskSize == 0 {
+		masterDiskSize = 20
+	}
+
+	workerDiskSize := cc.Spec.ProviderAwsSpec.WorkerDiskSize
+	if workerDiskSize == 0 {
+		workerDiskSize = 20
+	}
+
+	clm.AwsSpec = clusterV1alpha1.ProviderAwsSpec{
+		Region:         region,
+		MasterType:     masterType,
+		MasterDiskSize: masterDiskSize,
+		WorkerType:     workerType,
+		WorkerDiskSize: workerDiskSize,
+		SshKey:         cc.Spec.ProviderAwsSpec.SshKey,
+	}
+	return nil
+}
+
+// aws 인증 정보는 capa controller가 가지고 있으므로 설정할 것이 없다.
+func (p *awsProvider) LoadCredentials(ctx context.Context, c client.Client, clm *clusterV1alpha1.ClusterManager) error {
+	return nil
+}
+
+func (p *awsProvider) ClusterParams(clm *clusterV1alpha1.ClusterManager) []tmaxv1.ParamSpec {
+	spec := clm.AwsSpec
+	params := []tmaxv1.ParamSpec{
+		provider.BuildParam(AWS_PARAM_AWS_SSH_KEY_NAME, spec.SshKey, intstr.String),
+		provider.BuildParam(AWS_PARAM_AWS_REGION, spec.Region, intstr.String),
+		provider.BuildParam(AWS_PARAM_AWS_CONTROL_PLANE_MACHINE_TYPE, spec.MasterType, intstr.String),
+		provider.BuildParam(AWS_PARAM_AWS_NODE_MACHINE_TYPE, spec.WorkerType, intstr.String),
+		provider.BuildParam(AWS_PARAM_MASTER_DISK_SIZE, spec.MasterDiskSize, intstr.Int),
+		provider.BuildParam(AWS_PARAM_WORKER_DISK_SIZE, spec.WorkerDiskSize, intstr.Int),
+	}
+
+	return params
+}
+
+func (p *awsProvider) WorkerPoolParams(clm *clusterV1alpha1.ClusterManager, pool clusterV1alpha1.WorkerPool) []tmaxv1.ParamSpec {
+	spec := clm.AwsSpec
+	params := []tmaxv1.ParamSpec{
+		provider.BuildParam(AWS_PARAM_AWS_SSH_KEY_NAME, spec.SshKey, intstr.String),
+		provider.BuildParam(AWS_PARAM_AWS_NODE_MACHINE_TYPE, provider.DefaultString(pool.InstanceType, spec.WorkerType), intstr.String),
+		provider.BuildParam(AWS_PARAM_WORKER_DISK_SIZE, provider.DefaultInt(pool.DiskSize, spec.WorkerDiskSize), intstr.Int),
+	}
+	return params
+}
+
+// aws는 machine template을 교체하지 않고 version만 변경한다.
+func (p *awsProvider) PrepareUpgrade(clm *clusterV1alpha1.ClusterManager) provider.UpgradePlan {
+	return provider.UpgradePlan{}
+}
+
+func (p *awsProvider) HasNodeResources(nodeType string) bool {
+	_, ok := InstanceTypeResources[nodeType]
+	return ok
+}
+
+func (p *awsProvider) ControlPlaneResources(clm *clusterV1alpha1.ClusterManager) provider.NodeResources {
+	resources := InstanceTypeResources[clm.AwsSpec.MasterType]
+	resources.DiskSize = clm.AwsSpec.MasterDiskSize
+	return resources
+}
+
+func (p *awsProvider) WorkerResources(clm *clusterV1alpha1.ClusterManager, pool clusterV1alpha1.WorkerPool) provider.NodeResources {
+	resources := InstanceTypeResources[provider.DefaultString(pool.InstanceType, clm.AwsSpec.WorkerType)]
+	resources.DiskSize = provider.DefaultInt(pool.DiskSize, clm.AwsSpec.WorkerDiskSize)
+	return resources
+}
@@ -0,0 +1,60 @@
+package aws
+
+import (
+	"sort"
+
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/provider"
+
+	"sigs.k8s.io/yaml"
+)
+
+// awsPriceTable은 cost estimate ConfigMap의 AWS key에 작성하는 가격표
+// 사용하는 region의 가격을 작성한다.
+//
+//	instanceTypes:
+//	  t3.medium: 0.0416
+//	ebsPerGBMonth: 0.08
+type awsPriceTable struct {
+	// instance type별 시간당 가격
+	InstanceTypes map[string]float64 `json:"instanceTypes"`
+	// EBS volume의 GB당 월 가격
+	EbsPerGBMonth float64 `json:"ebsPerGBMonth"`
+}
+
+var _ provider.CostProvider = &awsProvider{}
+
+// EstimateHourlyCost는 master, worker pool별 instance type과 disk 크기로 시간당 비용을 계산한다.
+func (p *awsProvider) EstimateHourlyCost(clm *clusterV1alpha1.ClusterManager, priceTable string) (float64, []string, error) {
+	table := awsPriceTable{}
+	if err := yaml.Unmarshal([]byte(priceTable), &table); err != nil {
+		return 0, nil, err
+	}
+
+	cost := 0.0
+	missing := map[string]bool{}
+	add := func(instanceType string, diskSize, num int) {
+		if num <= 0 {
+			return
+		}
+		price, ok := table.InstanceTypes[instanceType]
+		if !ok {
+			missing[instanceType] = true
+		}
+		cost += (price + float64(diskSize)*table.EbsPerGBMonth/provider.HoursPerMonth) * float64(num)
+	}
+
+	spec := clm.AwsSpec
+	add(spec.MasterType, spec.MasterDiskSize, clm.Spec.MasterNum)
+	add(spec.WorkerType, spec.WorkerDiskSize, clm.Spec.WorkerNum)
+	for _, pool := range clm.Spec.WorkerPools {
+		add(provider.DefaultString(pool.InstanceType, spec.WorkerType), provider.DefaultInt(pool.DiskSize, spec.WorkerDiskSize), pool.Replicas)
+	}
+
+	missingTypes := []string{}
+	for t := range missing {
+		missingTypes = append(missingTypes, t)
+	}
+	sort.Strings(missingTypes)
+	return cost, missingTypes, nil
+}
@@ -0,0 +1,30 @@
+package aws
+
+import (
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/provider"
+)
+
+// InstanceTypeResources는 자주 사용하는 aws instance type별 vCPU 수, memory 크기(MB)
+// 목록에 없는 instance type은 resource를 0으로 계산하므로 cpu, memory quota가 있는 경우 claim을 거부한다.
+var InstanceTypeResources = map[string]provider.NodeResources{
+	"t3.micro":    {CpuNum: 2, MemSize: 1024},
+	"t3.small":    {CpuNum: 2, MemSize: 2048},
+	"t3.medium":   {CpuNum: 2, MemSize: 4096},
+	"t3.large":    {CpuNum: 2, MemSize: 8192},
+	"t3.xlarge":   {CpuNum: 4, MemSize: 16384},
+	"t3.2xlarge":  {CpuNum: 8, MemSize: 32768},
+	"m5.large":    {CpuNum: 2, MemSize: 8192},
+	"m5.xlarge":   {CpuNum: 4, MemSize: 16384},
+	"m5.2xlarge":  {CpuNum: 8, MemSize: 32768},
+	"m5.4xlarge":  {CpuNum: 16, MemSize: 65536},
+	"m5.8xlarge":  {CpuNum: 32, MemSize: 131072},
+	"c5.large":    {CpuNum: 2, MemSize: 4096},
+	"c5.xlarge":   {CpuNum: 4, MemSize: 8192},
+	"c5.2xlarge":  {CpuNum: 8, MemSize: 16384},
+	"c5.4xlarge":  {CpuNum: 16, MemSize: 32768},
+	"r5.large":    {CpuNum: 2, MemSize: 16384},
+	"r5.xlarge":   {CpuNum: 4, MemSize: 32768},
+	"r5.2xlarge":  {CpuNum: 8, MemSize: 65536},
+	"r5.4xlarge":  {CpuNum: 16, MemSize: 131072},
+	"g4dn.xlarge": {CpuNum: 4, MemSize: 16384},
+}
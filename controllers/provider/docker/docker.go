@@ -0,0 +1,147 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+
+	claimV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/claim/v1alpha1"
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/provider"
+	tmaxv1 "github.com/tmax-cloud/template-operator/api/v1"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	DefaultNodeImageRepository = "kindest/node"
+
+	DockerMachineTemplateApiVersion = "infrastructure.cluster.x-k8s.io/v1beta1"
+	DockerMachineTemplateKind       = "DockerMachineTemplate"
+)
+
+func init() {
+	provider.Register(&dockerProvider{})
+}
+
+// dockerProvider는 capd를 이용하여 node를 docker container로 생성한다.
+// VM이나 cloud 계정 없이 개발, CI 환경에서 cluster를 생성하기 위해 사용한다.
+// extraMounts는 template parameter로 치환할 수 없으므로 DockerMachineTemplate은 operator가 직접 생성한다.
+type dockerProvider struct{}
+
+var _ provider.MachineTemplateProvider = &dockerProvider{}
+
+func (p *dockerProvider) Name() string {
+	return clusterV1alpha1.ProviderDocker
+}
+
+func (p *dockerProvider) ValidateClaim(cc *claimV1alpha1.ClusterClaim) field.ErrorList {
+	path := field.NewPath("spec", "providerDockerSpec", "extraMounts")
+	errList := field.ErrorList{}
+	for i, mount := range cc.Spec.ProviderDockerSpec.ExtraMounts {
+		if mount.HostPath == "" {
+			errList = append(errList, field.Required(path.Index(i).Child("hostPath"), "hostPath is required"))
+		}
+		if mount.ContainerPath == "" {
+			errList = append(errList, field.Required(path.Index(i).Child("containerPath"), "containerPath is required"))
+		}
+	}
+	return errList
+}
+
+// docker spec configuration
+func (p *dockerProvider) ConvertClaim(cc *claimV1alpha1.ClusterClaim, clm *clusterV1alpha1.ClusterManager) error {
+	nodeImageRepository := cc.Spec.ProviderDockerSpec.NodeImageRepository
+	if nodeImageRepository == "" {
+		nodeImageRepository = DefaultNodeImageRepository
+	}
+
+	mounts := []clusterV1alpha1.DockerMount{}
+	for _, mount := range cc.Spec.ProviderDockerSpec.ExtraMounts {
+		mounts = append(mounts, clusterV1alpha1.DockerMount{
+			HostPath:      mount.HostPath,
+			ContainerPath: mount.ContainerPath,
+			ReadOnly:      mount.ReadOnly,
+		})
+	}
+
+	clm.DockerSpec = clusterV1alpha1.ProviderDockerSpec{
+		NodeImageRepository: nodeImageRepository,
+		ExtraMounts:         mounts,
+	}
+	return nil
+}
+
+// capd는 인증 정보가 필요 없다.
+func (p *dockerProvider) LoadCredentials(ctx context.Context, c client.Client, clm *clusterV1alpha1.ClusterManager) error {
+	return nil
+}
+
+func (p *dockerProvider) ClusterParams(clm *clusterV1alpha1.ClusterManager) []tmaxv1.ParamSpec {
+	return []tmaxv1.ParamSpec{}
+}
+
+func (p *dockerProvider) WorkerPoolParams(clm *clusterV1alpha1.ClusterManager, pool clusterV1alpha1.WorkerPool) []tmaxv1.ParamSpec {
+	return []tmaxv1.ParamSpec{}
+}
+
+func (p *dockerProvider) ControlPlaneMachineTemplate(clm *clusterV1alpha1.ClusterManager) *unstructured.Unstructured {
+	return buildMachineTemplate(clm, clm.Name+"-control-plane")
+}
+
+func (p *dockerProvider) WorkerMachineTemplate(clm *clusterV1alpha1.ClusterManager, pool clusterV1alpha1.WorkerPool) *unstructured.Unstructured {
+	return buildMachineTemplate(clm, clm.GetMachineDeploymentName(pool.Name))
+}
+
+// node image의 tag가 kubernetes version이므로
+// upgrade할 version의 DockerMachineTemplate을 controlplane, worker pool별로 새로 생성해야 한다.
+func (p *dockerProvider) PrepareUpgrade(clm *clusterV1alpha1.ClusterManager) provider.UpgradePlan {
+	controlplaneTemplateName := fmt.Sprintf("%s-controlplane-%s", clm.Name, clm.GetK8SVersion())
+	plan := provider.UpgradePlan{
+		ControlPlane: &provider.UpgradeTemplate{
+			Name:   controlplaneTemplateName,
+			Object: buildMachineTemplate(clm, controlplaneTemplateName),
+		},
+		WorkerPools: map[string]*provider.UpgradeTemplate{},
+	}
+
+	for _, pool := range clm.GetWorkerPools() {
+		workerTemplateName := fmt.Sprintf("%s-%s-%s", clm.Name, pool.Name, clm.GetK8SVersion())
+		plan.WorkerPools[pool.Name] = &provider.UpgradeTemplate{
+			Name:   workerTemplateName,
+			Object: buildMachineTemplate(clm, workerTemplateName),
+		}
+	}
+	return plan
+}
+
+func buildMachineTemplate(clm *clusterV1alpha1.ClusterManager, name string) *unstructured.Unstructured {
+	mounts := []interface{}{}
+	for _, mount := range clm.DockerSpec.ExtraMounts {
+		mounts = append(mounts, map[string]interface{}{
+			"hostPath":      mount.HostPath,
+			"containerPath": mount.ContainerPath,
+			"readOnly":      mount.ReadOnly,
+		})
+	}
+
+	nodeImageRepository := provider.DefaultString(clm.DockerSpec.NodeImageRepository, DefaultNodeImageRepository)
+	machineTemplate := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"customImage": nodeImageRepository + ":" + clm.GetK8SVersion(),
+						"extraMounts": mounts,
+					},
+				},
+			},
+		},
+	}
+	machineTemplate.SetAPIVersion(DockerMachineTemplateApiVersion)
+	machineTemplate.SetKind(DockerMachineTemplateKind)
+	machineTemplate.SetName(name)
+	machineTemplate.SetNamespace(clm.Namespace)
+	return machineTemplate
+}
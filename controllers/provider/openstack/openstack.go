@@ -0,0 +1,131 @@
+package openstack
+
+import (
+	"context"
+
+	claimV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/claim/v1alpha1"
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/provider"
+	tmaxv1 "github.com/tmax-cloud/template-operator/api/v1"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Openstack Parameter
+	OPENSTACK_PARAM_CLOUDS_SECRET_NAME           = "OPENSTACK_CLOUDS_SECRET_NAME"
+	OPENSTACK_PARAM_CLOUD                        = "OPENSTACK_CLOUD"
+	OPENSTACK_PARAM_CONTROL_PLANE_MACHINE_FLAVOR = "OPENSTACK_CONTROL_PLANE_MACHINE_FLAVOR"
+	OPENSTACK_PARAM_NODE_MACHINE_FLAVOR          = "OPENSTACK_NODE_MACHINE_FLAVOR"
+	OPENSTACK_PARAM_IMAGE_NAME                   = "OPENSTACK_IMAGE_NAME"
+	OPENSTACK_PARAM_NETWORK_NAME                 = "OPENSTACK_NETWORK_NAME"
+	OPENSTACK_PARAM_NODE_CIDR                    = "OPENSTACK_NODE_CIDR"
+	OPENSTACK_PARAM_EXTERNAL_NETWORK_ID          = "OPENSTACK_EXTERNAL_NETWORK_ID"
+	OPENSTACK_PARAM_SSH_KEY_NAME                 = "OPENSTACK_SSH_KEY_NAME"
+)
+
+func init() {
+	provider.Register(&openstackProvider{})
+}
+
+type openstackProvider struct{}
+
+func (p *openstackProvider) Name() string {
+	return clusterV1alpha1.ProviderOpenStack
+}
+
+// openstack은 인증 정보와 VM image를 기본값으로 채울 수 없으므로 반드시 입력해야 한다.
+func (p *openstackProvider) ValidateClaim(cc *claimV1alpha1.ClusterClaim) field.ErrorList {
+	spec := cc.Spec.ProviderOpenstackSpec
+	path := field.NewPath("spec", "providerOpenstackSpec")
+	errList := field.ErrorList{}
+	if spec.CloudsSecretName == "" {
+		errList = append(errList, field.Required(path.Child("cloudsSecretName"), "secret which has clouds.yaml is required"))
+	}
+	if spec.Image == "" {
+		errList = append(errList, field.Required(path.Child("image"), "image is required"))
+	}
+	return errList
+}
+
+// openstack spec configuration
+func (p *openstackProvider) ConvertClaim(cc *claimV1alpha1.ClusterClaim, clm *clusterV1alpha1.ClusterManager) error {
+	if errList := p.ValidateClaim(cc); len(errList) > 0 {
+		return errList.ToAggregate()
+	}
+	spec := cc.Spec.ProviderOpenstackSpec
+
+	cloudName := spec.CloudName
+	if cloudName == "" {
+		cloudName = "openstack"
+	}
+
+	masterFlavor := spec.MasterFlavor
+	if masterFlavor == "" {
+		masterFlavor = "m1.medium"
+	}
+
+	workerFlavor := spec.WorkerFlavor
+	if workerFlavor == "" {
+		workerFlavor = "m1.medium"
+	}
+
+	// 기존 network를 사용하지 않는 경우에만 nodeCidr로 network를 새로 생성한다.
+	nodeCidr := spec.NodeCidr
+	if nodeCidr == "" && spec.Network == "" {
+		nodeCidr = "10.6.0.0/24"
+	}
+
+	clm.OpenstackSpec = clusterV1alpha1.ProviderOpenstackSpec{
+		CloudsSecretName: spec.CloudsSecretName,
+		CloudName:        cloudName,
+		MasterFlavor:     masterFlavor,
+		WorkerFlavor:     workerFlavor,
+		Image:            spec.Image,
+		Network:          spec.Network,
+		NodeCidr:         nodeCidr,
+		FloatingIpPool:   spec.FloatingIpPool,
+		SshKey:           spec.SshKey,
+	}
+	return nil
+}
+
+// openstack 인증 정보는 capo가 cloudsSecretName의 secret을 직접 참조하므로 설정할 것이 없다.
+func (p *openstackProvider) LoadCredentials(ctx context.Context, c client.Client, clm *clusterV1alpha1.ClusterManager) error {
+	return nil
+}
+
+func (p *openstackProvider) ClusterParams(clm *clusterV1alpha1.ClusterManager) []tmaxv1.ParamSpec {
+	spec := clm.OpenstackSpec
+	params := []tmaxv1.ParamSpec{
+		provider.BuildParam(OPENSTACK_PARAM_CLOUDS_SECRET_NAME, spec.CloudsSecretName, intstr.String),
+		provider.BuildParam(OPENSTACK_PARAM_CLOUD, spec.CloudName, intstr.String),
+		provider.BuildParam(OPENSTACK_PARAM_CONTROL_PLANE_MACHINE_FLAVOR, spec.MasterFlavor, intstr.String),
+		provider.BuildParam(OPENSTACK_PARAM_NODE_MACHINE_FLAVOR, spec.WorkerFlavor, intstr.String),
+		provider.BuildParam(OPENSTACK_PARAM_IMAGE_NAME, spec.Image, intstr.String),
+		provider.BuildParam(OPENSTACK_PARAM_NETWORK_NAME, spec.Network, intstr.String),
+		provider.BuildParam(OPENSTACK_PARAM_NODE_CIDR, spec.NodeCidr, intstr.String),
+		provider.BuildParam(OPENSTACK_PARAM_EXTERNAL_NETWORK_ID, spec.FloatingIpPool, intstr.String),
+		provider.BuildParam(OPENSTACK_PARAM_SSH_KEY_NAME, spec.SshKey, intstr.String),
+	}
+	return params
+}
+
+func (p *openstackProvider) WorkerPoolParams(clm *clusterV1alpha1.ClusterManager, pool clusterV1alpha1.WorkerPool) []tmaxv1.ParamSpec {
+	spec := clm.OpenstackSpec
+	params := []tmaxv1.ParamSpec{
+		provider.BuildParam(OPENSTACK_PARAM_CLOUDS_SECRET_NAME, spec.CloudsSecretName, intstr.String),
+		provider.BuildParam(OPENSTACK_PARAM_CLOUD, spec.CloudName, intstr.String),
+		provider.BuildParam(OPENSTACK_PARAM_NODE_MACHINE_FLAVOR, provider.DefaultString(pool.InstanceType, spec.WorkerFlavor), intstr.String),
+		provider.BuildParam(OPENSTACK_PARAM_IMAGE_NAME, spec.Image, intstr.String),
+		provider.BuildParam(OPENSTACK_PARAM_SSH_KEY_NAME, spec.SshKey, intstr.String),
+	}
+	return params
+}
+
+// openstack은 machine template을 교체하지 않고 version만 변경한다.
+func (p *openstackProvider) PrepareUpgrade(clm *clusterV1alpha1.ClusterManager) provider.UpgradePlan {
+	return provider.UpgradePlan{}
+}
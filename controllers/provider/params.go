@@ -0,0 +1,46 @@
+package provider
+
+import (
+	tmaxv1 "github.com/tmax-cloud/template-operator/api/v1"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// BuildParam는 name과 value를 받아서 ParamSpec을 만들어 리턴
+// valueType이 intstr.String이면 value를 string으로 변환해서 리턴
+// valueType이 intstr.Int이면 value를 int로 변환해서 리턴(int만 가능)
+func BuildParam(name string, value interface{}, valueType intstr.Type) tmaxv1.ParamSpec {
+	paramSpec := tmaxv1.ParamSpec{}
+	if valueType == intstr.String {
+		paramSpec.Name = name
+		paramSpec.Value = intstr.IntOrString{
+			Type:   intstr.String,
+			StrVal: value.(string),
+		}
+	} else if valueType == intstr.Int {
+		paramSpec.Name = name
+		value := int32(value.(int))
+		paramSpec.Value = intstr.IntOrString{
+			Type:   intstr.Int,
+			IntVal: value,
+		}
+	} else {
+		return tmaxv1.ParamSpec{}
+	}
+
+	return paramSpec
+}
+
+func DefaultString(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+func DefaultInt(value, defaultValue int) int {
+	if value == 0 {
+		return defaultValue
+	}
+	return value
+}
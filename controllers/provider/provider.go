@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	claimV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/claim/v1alpha1"
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	tmaxv1 "github.com/tmax-cloud/template-operator/api/v1"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Provider는 cluster를 생성하는 infrastructure provider별 로직을 정의한다.
+// provider를 추가하려면 이 interface를 구현한 package를 만들고 init에서 Register를 호출하면 된다.
+type Provider interface {
+	// Name은 spec.provider에 사용하는 provider 이름을 반환한다. (e.g. AWS, vSphere)
+	Name() string
+
+	// ValidateClaim은 cluster claim의 provider spec을 검증한다.
+	ValidateClaim(cc *claimV1alpha1.ClusterClaim) field.ErrorList
+
+	// ConvertClaim은 cluster claim의 provider spec에 기본값을 채워 cluster manager에 설정한다.
+	ConvertClaim(cc *claimV1alpha1.ClusterClaim, clm *clusterV1alpha1.ClusterManager) error
+
+	// LoadCredentials는 cluster 생성에 필요한 인증 정보를 cluster manager에 설정한다.
+	LoadCredentials(ctx context.Context, c client.Client, clm *clusterV1alpha1.ClusterManager) error
+
+	// ClusterParams는 cluster template instance의 provider parameter를 만든다.
+	ClusterParams(clm *clusterV1alpha1.ClusterManager) []tmaxv1.ParamSpec
+
+	// WorkerPoolParams는 worker pool template instance의 provider parameter를 만든다.
+	// pool에 지정하지 않은 VM 사양은 cluster manager의 provider spec 값을 사용해야 한다.
+	WorkerPoolParams(clm *clusterV1alpha1.ClusterManager, pool clusterV1alpha1.WorkerPool) []tmaxv1.ParamSpec
+
+	// PrepareUpgrade는 upgrade 전에 생성해야 하는 machine template 목록을 반환한다.
+	// version만 변경하면 되는 provider는 빈 UpgradePlan을 반환한다.
+	PrepareUpgrade(clm *clusterV1alpha1.ClusterManager) UpgradePlan
+}
+
+// MachineTemplateProvider는 machine template을 template instance가 아닌 operator가 직접 생성해야 하는 provider가 구현한다.
+// template parameter는 string, number만 치환할 수 있으므로 list 등을 포함하는 machine template은 이 방식으로 생성한다.
+// 생성한 machine template의 이름은 cluster template에서 참조하는 이름과 같아야 한다.
+type MachineTemplateProvider interface {
+	// ControlPlaneMachineTemplate은 {cluster}-control-plane machine template을 만든다.
+	ControlPlaneMachineTemplate(clm *clusterV1alpha1.ClusterManager) *unstructured.Unstructured
+
+	// WorkerMachineTemplate은 worker pool의 {cluster}-{pool} machine template을 만든다.
+	WorkerMachineTemplate(clm *clusterV1alpha1.ClusterManager, pool clusterV1alpha1.WorkerPool) *unstructured.Unstructured
+}
+
+// NodeResources는 node 하나의 vCPU 수, memory 크기(MB), disk 크기(GB)
+type NodeResources struct {
+	CpuNum   int
+	MemSize  int
+	DiskSize int
+}
+
+// NodeResourceProvider는 VM 사양으로 node의 resource를 계산할 수 있는 provider가 구현한다.
+// quota 등에서 cluster의 resource 사용량을 계산할 때 사용한다.
+type NodeResourceProvider interface {
+	// ControlPlaneResources는 master node 하나의 resource를 반환한다.
+	ControlPlaneResources(clm *clusterV1alpha1.ClusterManager) NodeResources
+
+	// WorkerResources는 worker pool의 node 하나의 resource를 반환한다.
+	// 기본 pool(md-0)은 pool 사양을 지정하지 않은 WorkerPool로 계산한다.
+	WorkerResources(clm *clusterV1alpha1.ClusterManager, pool clusterV1alpha1.WorkerPool) NodeResources
+}
+
+// NodeTypeProvider는 VM 사양을 instance type 등의 이름으로 지정하는 provider가 구현한다.
+type NodeTypeProvider interface {
+	// HasNodeResources는 이름으로 지정한 VM 사양의 resource를 알고 있는지 확인한다.
+	HasNodeResources(nodeType string) bool
+}
+
+// HasNodeResources는 provider가 이름으로 지정한 VM 사양의 resource를 계산할 수 있는지 확인한다.
+// NodeTypeProvider를 구현하지 않은 provider는 true를 반환한다.
+func HasNodeResources(providerName, nodeType string) bool {
+	p, err := Get(providerName)
+	if err != nil {
+		return true
+	}
+	tp, ok := p.(NodeTypeProvider)
+	return !ok || tp.HasNodeResources(nodeType)
+}
+
+// ClusterResources는 cluster의 전체 node resource를 계산한다.
+// provider가 NodeResourceProvider를 구현하지 않았거나 사양을 알 수 없는 경우 0으로 계산한다.
+func ClusterResources(clm *clusterV1alpha1.ClusterManager) NodeResources {
+	total := NodeResources{}
+	p, err := Get(clm.Spec.Provider)
+	if err != nil {
+		return total
+	}
+	rp, ok := p.(NodeResourceProvider)
+	if !ok {
+		return total
+	}
+
+	add := func(r NodeResources, num int) {
+		total.CpuNum += r.CpuNum * num
+		total.MemSize += r.MemSize * num
+		total.DiskSize += r.DiskSize * num
+	}
+	add(rp.ControlPlaneResources(clm), clm.Spec.MasterNum)
+	add(rp.WorkerResources(clm, clusterV1alpha1.WorkerPool{}), clm.Spec.WorkerNum)
+	for _, pool := range clm.Spec.WorkerPools {
+		add(rp.WorkerResources(clm, pool), pool.Replicas)
+	}
+	return total
+}
+
+// 월 비용을 계산할 때 사용하는 한 달의 시간
+const HoursPerMonth = 730
+
+// CostProvider는 가격표로 cluster의 비용을 계산할 수 있는 provider가 구현한다.
+// 가격표는 cost estimate ConfigMap의 provider 이름 key에 provider가 정의한 YAML 형식으로 작성한다.
+type CostProvider interface {
+	// EstimateHourlyCost는 가격표로 cluster의 시간당 비용을 계산한다.
+	// 가격표에 없는 항목은 비용을 0으로 계산하고 missing으로 반환한다.
+	EstimateHourlyCost(clm *clusterV1alpha1.ClusterManager, priceTable string) (cost float64, missing []string, err error)
+}
+
+// UpgradeTemplate은 upgrade용 machine template을 생성하기 위한 template instance 정보
+type UpgradeTemplate struct {
+	// template instance 이름이자 생성되는 machine template 이름
+	Name string
+	// template instance를 생성할 cluster template 이름
+	TemplateName string
+	Parameters   []tmaxv1.ParamSpec
+	// Object가 있으면 template instance 대신 Object를 직접 생성한다.
+	Object *unstructured.Unstructured
+}
+
+// UpgradePlan은 upgrade 시 controlplane, worker pool별로 교체할 machine template 정보
+// nil인 경우 machine template은 교체하지 않고 version만 변경한다.
+type UpgradePlan struct {
+	ControlPlane *UpgradeTemplate
+	WorkerPools  map[string]*UpgradeTemplate
+}
+
+// Templates는 생성해야 하는 모든 upgrade template을 반환한다.
+func (p UpgradePlan) Templates() []UpgradeTemplate {
+	templates := []UpgradeTemplate{}
+	if p.ControlPlane != nil {
+		templates = append(templates, *p.ControlPlane)
+	}
+
+	names := []string{}
+	for name := range p.WorkerPools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if p.WorkerPools[name] != nil {
+			templates = append(templates, *p.WorkerPools[name])
+		}
+	}
+	return templates
+}
+
+var (
+	providersMu sync.RWMutex
+	providers   = map[string]Provider{}
+)
+
+// Register는 provider를 등록한다. 이름은 대소문자를 구분하지 않는다.
+func Register(p Provider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	key := strings.ToUpper(p.Name())
+	if _, ok := providers[key]; ok {
+		panic("provider " + p.Name() + " is already registered")
+	}
+	providers[key] = p
+}
+
+// Get은 이름으로 등록된 provider를 찾는다.
+func Get(name string) (Provider, error) {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	p, ok := providers[strings.ToUpper(name)]
+	if !ok {
+		return nil, fmt.Errorf("provider [%s] is not supported", name)
+	}
+	return p, nil
+}
+
+// ValidateClaim은 cluster claim의 provider에 맞는 provider spec 검증을 수행한다.
+func ValidateClaim(cc *claimV1alpha1.ClusterClaim) field.ErrorList {
+	p, err := Get(cc.Spec.Provider)
+	if err != nil {
+		return field.ErrorList{field.NotSupported(field.NewPath("spec", "provider"), cc.Spec.Provider, Names())}
+	}
+	return p.ValidateClaim(cc)
+}
+
+// Names는 등록된 provider 이름 목록을 반환한다.
+func Names() []string {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	names := []string{}
+	for _, p := range providers {
+		names = append(names, p.Name())
+	}
+	sort.Strings(names)
+	return names
+}
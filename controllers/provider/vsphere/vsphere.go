@@ -0,0 +1,301 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	claimV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/claim/v1alpha1"
+	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/provider"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/util"
+	tmaxv1 "github.com/tmax-cloud/template-operator/api/v1"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// upgrade template
+	CAPI_VSPHERE_UPGRADE_TEMPLATE = "capi-vsphere-upgrade-template"
+)
+
+const (
+	// Vsphere Parameter
+	VSPHERE_PARAM_POD_CIDR                  = "POD_CIDR"
+	VSPHERE_PARAM_VSPHERE_SERVER            = "VSPHERE_SERVER"
+	VSPHERE_PARAM_VSPHERE_USERNAME          = "VSPHERE_USERNAME"
+	VSPHERE_PARAM_VSPHERE_PASSWORD          = "VSPHERE_PASSWORD"
+	VSPHERE_PARAM_VSPHERE_TLS_THUMBPRINT    = "VSPHERE_TLS_THUMBPRINT"
+	VSPHERE_PARAM_VSPHERE_NETWORK           = "VSPHERE_NETWORK"
+	VSPHERE_PARAM_VSPHERE_DATACENTER        = "VSPHERE_DATACENTER"
+	VSPHERE_PARAM_VSPHERE_DATASTORE         = "VSPHERE_DATASTORE"
+	VSPHERE_PARAM_VSPHERE_FOLDER            = "VSPHERE_FOLDER"
+	VSPHERE_PARAM_VSPHERE_RESOURCE_POOL     = "VSPHERE_RESOURCE_POOL"
+	VSPHERE_PARAM_MASTER_CPU_NUM            = "MASTER_CPU_NUM"
+	VSPHERE_PARAM_MASTER_MEM_SIZE           = "MASTER_MEM_SIZE"
+	VSPHERE_PARAM_MASTER_DISK_SIZE          = "MASTER_DISK_SIZE"
+	VSPHERE_PARAM_WORKER_CPU_NUM            = "WORKER_CPU_NUM"
+	VSPHERE_PARAM_WORKER_MEM_SIZE           = "WORKER_MEM_SIZE"
+	VSPHERE_PARAM_WORKER_DISK_SIZE          = "WORKER_DISK_SIZE"
+	VSPHERE_PARAM_VSPHERE_TEMPLATE          = "VSPHERE_TEMPLATE"
+	VSPHERE_PARAM_VM_PASSWORD               = "VM_PASSWORD"
+	VSPHERE_PARAM_CONTROL_PLANE_ENDPOINT_IP = "CONTROL_PLANE_ENDPOINT_IP"
+
+	// Vsphere UpgradeParameter
+	VSPHERE_UPGRADE_PARAM_NAMESPACE              = "NAMESPACE"
+	VSPHERE_UPGRADE_PARAM_UPGRADE_TEMPLATE_NAME  = "UPGRADE_TEMPLATE_NAME"
+	VSPHERE_UPGRADE_PARAM_VSPHERE_SERVER         = "VSPHERE_SERVER"
+	VSPHERE_UPGRADE_PARAM_VSPHERE_TLS_THUMBPRINT = "VSPHERE_TLS_THUMBPRINT"
+	VSPHERE_UPGRADE_PARAM_VSPHERE_NETWORK        = "VSPHERE_NETWORK"
+	VSPHERE_UPGRADE_PARAM_VSPHERE_DATACENTER     = "VSPHERE_DATACENTER"
+	VSPHERE_UPGRADE_PARAM_VSPHERE_DATASTORE      = "VSPHERE_DATASTORE"
+	VSPHERE_UPGRADE_PARAM_VSPHERE_FOLDER         = "VSPHERE_FOLDER"
+	VSPHERE_UPGRADE_PARAM_VSPHERE_RESOURCE_POOL  = "VSPHERE_RESOURCE_POOL"
+	VSPHERE_UPGRADE_PARAM_CPU_NUM                = "CPU_NUM"
+	VSPHERE_UPGRADE_PARAM_MEM_SIZE               = "MEM_SIZE"
+	VSPHERE_UPGRADE_PARAM_DISK_SIZE              = "DISK_SIZE"
+	VSPHERE_UPGRADE_PARAM_VSPHERE_TEMPLATE       = "VSPHERE_TEMPLATE"
+	VSPHERE_UPGRADE_PARAM_KUBERNETES_VERSION     = "KUBERNETES_VERSION"
+)
+
+func init() {
+	provider.Register(&vsphereProvider{})
+}
+
+type vsphereProvider struct{}
+
+func (p *vsphereProvider) Name() string {
+	return clusterV1alpha1.ProviderVSphere
+}
+
+func (p *vsphereProvider) ValidateClaim(cc *claimV1alpha1.ClusterClaim) field.ErrorList {
+	errList := field.ErrorList{}
+	thumbprint := cc.Spec.ProviderVsphereSpec.VcenterThumbprint
+	if _, err := util.AddColonToThumbprint(thumbprint); err != nil {
+		path := field.NewPath("spec", "providerVsphereSpec", "vcenterThumbprint")
+		errList = append(errList, field.Invalid(path, thumbprint, err.Error()))
+	}
+	return errList
+}
+
+// vsphere spec configuration
+func (p *vsphereProvider) ConvertClaim(cc *claimV1alpha1.ClusterClaim, clm *clusterV1alpha1.ClusterManager) error {
+
+	podCidr := cc.Spec.ProviderVsphereSpec.PodCidr
+	if podCidr == "" {
+		podCidr = "10.0.0.0/16"
+	}
+
+	thumbPrint, err := util.AddColonToThumbprint(cc.Spec.ProviderVsphereSpec.VcenterThumbprint)
+	if err != nil {
+		return err
+	}
+
+	vmNetwork := cc.Spec.ProviderVsphereSpec.VcenterNetwork
+	if vmNetwork == "" {
+		vmNetwork = "VM Network"
+	}
+
+	vcenterFolder := cc.Spec.ProviderVsphereSpec.VcenterFolder
+	if vcenterFolder == "" {
+		vcenterFolder = "vm"
+	}
+
+	cpu := cc.Spec.ProviderVsphereSpec.VcenterCpuNum
+	if cpu == 0 {
+		cpu = 2
+	}
+
+	mem := cc.Spec.ProviderVsphereSpec.VcenterMemSize
+	if mem == 0 {
+		mem = 4096
+	}
+
+	diskSize := cc.Spec.ProviderVsphereSpec.VcenterDiskSize
+	if diskSize == 0 {
+		diskSize = 20
+	}
+
+	vmPassword := cc.Spec.ProviderVsphereSpec.VMPassword
+	if vmPassword == "" {
+		vmPassword = "dG1heEAyMw=="
+	}
+
+	clm.VsphereSpec = clusterV1alpha1.ProviderVsphereSpec{
+		PodCidr:             podCidr,
+		VcenterCpuNum:       cpu,
+		VcenterMemSize:      mem,
+		VcenterDiskSize:     diskSize,
+		VcenterThumbprint:   thumbPrint,
+		VcenterNetwork:      vmNetwork,
+		VcenterFolder:       vcenterFolder,
+		VMPassword:          vmPassword,
+		VcenterIp:           cc.Spec.ProviderVsphereSpec.VcenterIp,
+		VcenterDataCenter:   cc.Spec.ProviderVsphereSpec.VcenterDataCenter,
+		VcenterDataStore:    cc.Spec.ProviderVsphereSpec.VcenterDataStore,
+		VcenterResourcePool: cc.Spec.ProviderVsphereSpec.VcenterResourcePool,
+		VcenterKcpIp:        cc.Spec.ProviderVsphereSpec.VcenterKcpIp,
+		VcenterTemplate:     cc.Spec.ProviderVsphereSpec.VcenterTemplate,
+	}
+	return nil
+}
+
+// LoadCredentials는 capv의 bootstrap credential secret에서 vcenter 계정 정보를 가져온다.
+func (p *vsphereProvider) LoadCredentials(ctx context.Context, c client.Client, clm *clusterV1alpha1.ClusterManager) error {
+
+	key := types.NamespacedName{
+		Name:      "capv-manager-bootstrap-credentials",
+		Namespace: "capv-system",
+	}
+
+	credential := &coreV1.Secret{}
+
+	if err := c.Get(ctx, key, credential); err != nil {
+		return err
+	}
+
+	credentials, ok := credential.Data["credentials.yaml"]
+	if !ok {
+		return fmt.Errorf("credentials info not found in vsphere credential secret")
+	}
+
+	lines := strings.Split(string(credentials), "\n")
+	username := strings.Split(lines[0], ":")[1]
+	password := strings.Split(lines[1], ":")[1]
+
+	username = strings.TrimSpace(username)
+	password = strings.TrimSpace(password)
+
+	if username == "" || password == "" {
+		return fmt.Errorf("username or password not found in vsphere credential secret")
+	}
+
+	clm.VsphereSpec.VcenterId = username
+	clm.VsphereSpec.VcenterPassword = password
+	return nil
+}
+
+func (p *vsphereProvider) ClusterParams(clm *clusterV1alpha1.ClusterManager) []tmaxv1.ParamSpec {
+	spec := clm.VsphereSpec
+	params := []tmaxv1.ParamSpec{
+		provider.BuildParam(VSPHERE_PARAM_POD_CIDR, spec.PodCidr, intstr.String),
+		provider.BuildParam(VSPHERE_PARAM_VSPHERE_SERVER, spec.VcenterIp, intstr.String),
+		provider.BuildParam(VSPHERE_PARAM_VSPHERE_USERNAME, spec.VcenterId, intstr.String),
+		provider.BuildParam(VSPHERE_PARAM_VSPHERE_PASSWORD, spec.VcenterPassword, intstr.String),
+		provider.BuildParam(VSPHERE_PARAM_VSPHERE_TLS_THUMBPRINT, spec.VcenterThumbprint, intstr.String),
+		provider.BuildParam(VSPHERE_PARAM_VSPHERE_NETWORK, spec.VcenterNetwork, intstr.String),
+		provider.BuildParam(VSPHERE_PARAM_VSPHERE_DATACENTER, spec.VcenterDataCenter, intstr.String),
+		provider.BuildParam(VSPHERE_PARAM_VSPHERE_DATASTORE, spec.VcenterDataStore, intstr.String),
+		provider.BuildParam(VSPHERE_PARAM_VSPHERE_FOLDER, spec.VcenterFolder, intstr.String),
+		provider.BuildParam(VSPHERE_PARAM_VSPHERE_RESOURCE_POOL, spec.VcenterResourcePool, intstr.String),
+		provider.BuildParam(VSPHERE_PARAM_CONTROL_PLANE_ENDPOINT_IP, spec.VcenterKcpIp, intstr.String),
+		provider.BuildParam(VSPHERE_PARAM_MASTER_CPU_NUM, spec.VcenterCpuNum, intstr.Int),
+		provider.BuildParam(VSPHERE_PARAM_MASTER_MEM_SIZE, spec.VcenterMemSize, intstr.Int),
+		provider.BuildParam(VSPHERE_PARAM_MASTER_DISK_SIZE, spec.VcenterDiskSize, intstr.Int),
+		provider.BuildParam(VSPHERE_PARAM_WORKER_CPU_NUM, spec.VcenterCpuNum, intstr.Int),
+		provider.BuildParam(VSPHERE_PARAM_WORKER_MEM_SIZE, spec.VcenterMemSize, intstr.Int),
+		provider.BuildParam(VSPHERE_PARAM_WORKER_DISK_SIZE, spec.VcenterDiskSize, intstr.Int),
+		provider.BuildParam(VSPHERE_PARAM_VSPHERE_TEMPLATE, spec.VcenterTemplate, intstr.String),
+		provider.BuildParam(VSPHERE_PARAM_VM_PASSWORD, spec.VMPassword, intstr.String),
+	}
+	return params
+}
+
+func (p *vsphereProvider) WorkerPoolParams(clm *clusterV1alpha1.ClusterManager, pool clusterV1alpha1.WorkerPool) []tmaxv1.ParamSpec {
+	spec := clm.VsphereSpec
+	params := []tmaxv1.ParamSpec{
+		provider.BuildParam(VSPHERE_PARAM_VSPHERE_SERVER, spec.VcenterIp, intstr.String),
+		provider.BuildParam(VSPHERE_PARAM_VSPHERE_TLS_THUMBPRINT, spec.VcenterThumbprint, intstr.String),
+		provider.BuildParam(VSPHERE_PARAM_VSPHERE_NETWORK, spec.VcenterNetwork, intstr.String),
+		provider.BuildParam(VSPHERE_PARAM_VSPHERE_DATACENTER, spec.VcenterDataCenter, intstr.String),
+		provider.BuildParam(VSPHERE_PARAM_VSPHERE_DATASTORE, spec.VcenterDataStore, intstr.String),
+		provider.BuildParam(VSPHERE_PARAM_VSPHERE_FOLDER, spec.VcenterFolder, intstr.String),
+		provider.BuildParam(VSPHERE_PARAM_VSPHERE_RESOURCE_POOL, spec.VcenterResourcePool, intstr.String),
+		provider.BuildParam(VSPHERE_PARAM_WORKER_CPU_NUM, provider.DefaultInt(pool.CpuNum, spec.VcenterCpuNum), intstr.Int),
+		provider.BuildParam(VSPHERE_PARAM_WORKER_MEM_SIZE, provider.DefaultInt(pool.MemSize, spec.VcenterMemSize), intstr.Int),
+		provider.BuildParam(VSPHERE_PARAM_WORKER_DISK_SIZE, provider.DefaultInt(pool.DiskSize, spec.VcenterDiskSize), intstr.Int),
+		provider.BuildParam(VSPHERE_PARAM_VSPHERE_TEMPLATE, spec.VcenterTemplate, intstr.String),
+		provider.BuildParam(VSPHERE_PARAM_VM_PASSWORD, spec.VMPassword, intstr.String),
+	}
+	return params
+}
+
+func (p *vsphereProvider) ControlPlaneResources(clm *clusterV1alpha1.ClusterManager) provider.NodeResources {
+	return provider.NodeResources{
+		CpuNum:   clm.VsphereSpec.VcenterCpuNum,
+		MemSize:  clm.VsphereSpec.VcenterMemSize,
+		DiskSize: clm.VsphereSpec.VcenterDiskSize,
+	}
+}
+
+func (p *vsphereProvider) WorkerResources(clm *clusterV1alpha1.ClusterManager, pool clusterV1alpha1.WorkerPool) provider.NodeResources {
+	return provider.NodeResources{
+		CpuNum:   provider.DefaultInt(pool.CpuNum, clm.VsphereSpec.VcenterCpuNum),
+		MemSize:  provider.DefaultInt(pool.MemSize, clm.VsphereSpec.VcenterMemSize),
+		DiskSize: provider.DefaultInt(pool.DiskSize, clm.VsphereSpec.VcenterDiskSize),
+	}
+}
+
+// vsphere는 VM template이 kubernetes version별로 다르므로
+// upgrade할 version의 VSphereMachineTemplate을 controlplane, worker pool별로 새로 생성해야 한다.
+func (p *vsphereProvider) PrepareUpgrade(clm *clusterV1alpha1.ClusterManager) provider.UpgradePlan {
+	spec := clm.VsphereSpec
+	controlplaneTemplateName := fmt.Sprintf("%s-controlplane-%s", clm.Name, clm.GetK8SVersion())
+	plan := provider.UpgradePlan{
+		ControlPlane: &provider.UpgradeTemplate{
+			Name:         controlplaneTemplateName,
+			TemplateName: CAPI_VSPHERE_UPGRADE_TEMPLATE,
+			Parameters: buildUpgradeParams(clm, controlplaneTemplateName,
+				spec.VcenterCpuNum, spec.VcenterMemSize, spec.VcenterDiskSize),
+		},
+		WorkerPools: map[string]*provider.UpgradeTemplate{},
+	}
+
+	for _, pool := range clm.GetWorkerPools() {
+		workerTemplateName := getUpgradeWorkerTemplateName(clm, pool.Name)
+		plan.WorkerPools[pool.Name] = &provider.UpgradeTemplate{
+			Name:         workerTemplateName,
+			TemplateName: CAPI_VSPHERE_UPGRADE_TEMPLATE,
+			Parameters: buildUpgradeParams(clm, workerTemplateName,
+				provider.DefaultInt(pool.CpuNum, spec.VcenterCpuNum),
+				provider.DefaultInt(pool.MemSize, spec.VcenterMemSize),
+				provider.DefaultInt(pool.DiskSize, spec.VcenterDiskSize)),
+		}
+	}
+	return plan
+}
+
+// buildUpgradeParams는 upgrade용 VSphereMachineTemplate을 생성하기 위한 parameter를 만든다.
+// controlplane과 worker pool마다 VM 사양이 다를 수 있으므로 사양을 인자로 받는다.
+func buildUpgradeParams(clm *clusterV1alpha1.ClusterManager, upgradeTemplateName string, cpuNum, memSize, diskSize int) []tmaxv1.ParamSpec {
+	params := []tmaxv1.ParamSpec{
+		provider.BuildParam(VSPHERE_UPGRADE_PARAM_NAMESPACE, clm.Namespace, intstr.String),
+		provider.BuildParam(VSPHERE_UPGRADE_PARAM_UPGRADE_TEMPLATE_NAME, upgradeTemplateName, intstr.String),
+		provider.BuildParam(VSPHERE_UPGRADE_PARAM_VSPHERE_SERVER, clm.VsphereSpec.VcenterIp, intstr.String),
+		provider.BuildParam(VSPHERE_UPGRADE_PARAM_VSPHERE_TLS_THUMBPRINT, clm.VsphereSpec.VcenterThumbprint, intstr.String),
+		provider.BuildParam(VSPHERE_UPGRADE_PARAM_VSPHERE_NETWORK, clm.VsphereSpec.VcenterNetwork, intstr.String),
+		provider.BuildParam(VSPHERE_UPGRADE_PARAM_VSPHERE_DATACENTER, clm.VsphereSpec.VcenterDataCenter, intstr.String),
+		provider.BuildParam(VSPHERE_UPGRADE_PARAM_VSPHERE_DATASTORE, clm.VsphereSpec.VcenterDataStore, intstr.String),
+		provider.BuildParam(VSPHERE_UPGRADE_PARAM_VSPHERE_FOLDER, clm.VsphereSpec.VcenterFolder, intstr.String),
+		provider.BuildParam(VSPHERE_UPGRADE_PARAM_VSPHERE_RESOURCE_POOL, clm.VsphereSpec.VcenterResourcePool, intstr.String),
+		provider.BuildParam(VSPHERE_UPGRADE_PARAM_VSPHERE_TEMPLATE, clm.VsphereSpec.VcenterTemplate, intstr.String),
+		provider.BuildParam(VSPHERE_UPGRADE_PARAM_CPU_NUM, cpuNum, intstr.Int),
+		provider.BuildParam(VSPHERE_UPGRADE_PARAM_MEM_SIZE, memSize, intstr.Int),
+		provider.BuildParam(VSPHERE_UPGRADE_PARAM_DISK_SIZE, diskSize, intstr.Int),
+		provider.BuildParam(VSPHERE_UPGRADE_PARAM_KUBERNETES_VERSION, clm.Spec.Version, intstr.String),
+	}
+	return params
+}
+
+// getUpgradeWorkerTemplateName은 worker pool의 upgrade용 VSphereMachineTemplate 이름을 반환한다.
+// 기본 worker pool은 기존 이름 규칙({cluster}-worker-{version})을 유지한다.
+func getUpgradeWorkerTemplateName(clm *clusterV1alpha1.ClusterManager, poolName string) string {
+	if poolName == clusterV1alpha1.DefaultWorkerPoolName {
+		return fmt.Sprintf("%s-worker-%s", clm.Name, clm.GetK8SVersion())
+	}
+	return fmt.Sprintf("%s-%s-%s", clm.Name, poolName, clm.GetK8SVersion())
+}
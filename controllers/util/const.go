@@ -14,6 +14,10 @@ limitations under the License.
 
 package util
 
+import (
+	claimV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/claim/v1alpha1"
+)
+
 const (
 	KubeNamespace          = "kube-system"
 	ApiGatewayNamespace    = "api-gateway-system"
@@ -45,6 +49,12 @@ const (
 	ProviderVsphere     = "VSPHERE"
 	ProviderVsphereLogo = "vSphere"
 
+	ProviderOpenstack     = "OPENSTACK"
+	ProviderOpenstackLogo = "OpenStack"
+
+	ProviderDocker     = "DOCKER"
+	ProviderDockerLogo = "Docker"
+
 	ProviderUnknown = "Unknown"
 )
 
@@ -68,7 +78,7 @@ const (
 
 const (
 	AnnotationKeyOwner   = "owner"
-	AnnotationKeyCreator = "creator"
+	AnnotationKeyCreator = claimV1alpha1.AnnotationKeyClaimCreator
 
 	AnnotationKeyArgoClusterSecret = "argocd.argoproj.io/cluster.secret"
 	AnnotationKeyArgoManagedBy     = "managed-by"
@@ -129,6 +139,10 @@ const (
 	AUTH_CLIENT_SECRET = "AUTH_CLIENT_SECRET"
 	AUTH_SUBDOMAIN     = "AUTH_SUBDOMAIN"
 	// AUDIT_WEBHOOK_SERVER_PATH = "AUDIT_WEBHOOK_SERVER_PATH"
+	// operator의 namespace와 service account. downward API로 주입한다.
+	// webhook에서 operator의 요청을 구분할 수 없으면 권한 검사를 할 수 없으므로 필수로 한다.
+	POD_NAMESPACE       = "POD_NAMESPACE"
+	POD_SERVICE_ACCOUNT = "POD_SERVICE_ACCOUNT"
 
 	// optional 환경 변수
 	ARGO_APP_DELETE = "ARGO_APP_DELETE"
@@ -142,5 +156,7 @@ func GetRequiredEnvPreset() []string {
 		AUTH_CLIENT_SECRET,
 		AUTH_SUBDOMAIN,
 		// AUDIT_WEBHOOK_SERVER_PATH,
+		POD_NAMESPACE,
+		POD_SERVICE_ACCOUNT,
 	}
 }
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/metrics"
 )
 
 func Delete(namespace, cluster string) error {
@@ -21,7 +22,7 @@ func Delete(namespace, cluster string) error {
 	}
 	url = strings.Replace(url, "{namespace}", namespace, -1)
 	url = strings.Replace(url, "{clustermanager}", cluster, -1)
-	client := &http.Client{Transport: tr}
+	client := &http.Client{Transport: metrics.InstrumentRoundTripper(metrics.ServiceHypercloudApi, tr)}
 	req, err := http.NewRequest("DELETE", url, nil)
 	if err != nil {
 		log.Fatalf("An Error Occurred %v", err)
@@ -47,7 +48,7 @@ func Insert(clusterManager *clusterV1alpha1.ClusterManager) error {
 	// http.
 	url = strings.Replace(url, "{namespace}", clusterManager.Namespace, -1)
 	url = strings.Replace(url, "{clustermanager}", clusterManager.Name, -1)
-	client := &http.Client{Transport: tr}
+	client := &http.Client{Transport: metrics.InstrumentRoundTripper(metrics.ServiceHypercloudApi, tr)}
 
 	// person := Person{"Alex", 10}
 	data, _ := json.Marshal(clusterManager)
@@ -70,7 +71,7 @@ func List(namespace, cluster string) ([]byte, error) {
 	url = strings.Replace(url, "{namespace}", namespace, -1)
 	url = strings.Replace(url, "{clustermanager}", cluster, -1)
 	url = strings.Replace(url, "{member}", "all", -1)
-	client := &http.Client{Transport: tr}
+	client := &http.Client{Transport: metrics.InstrumentRoundTripper(metrics.ServiceHypercloudApi, tr)}
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		log.Fatalf("An Error Occurred %v", err)
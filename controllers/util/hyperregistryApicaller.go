@@ -8,6 +8,7 @@ import (
 	"net/http/httputil"
 	"strings"
 
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/metrics"
 	coreV1 "k8s.io/api/core/v1"
 )
 
@@ -49,7 +50,7 @@ func SetHyperregistryOIDC(config OidcConfig, secret *coreV1.Secret, hostpath str
 	req.SetBasicAuth("admin", password)
 	req.Header.Add("Content-Type", "application/json")
 
-	client := &http.Client{}
+	client := &http.Client{Transport: metrics.InstrumentRoundTripper(metrics.ServiceHyperregistry, nil)}
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
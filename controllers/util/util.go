@@ -1,6 +1,8 @@
 package util
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"hash/fnv"
 	"math/rand"
@@ -38,6 +40,12 @@ func LowestNonZeroResult(i, j ctrl.Result) ctrl.Result {
 }
 
 func GetRemoteK8sClient(secret *coreV1.Secret) (*kubernetes.Clientset, error) {
+	return GetRemoteK8sClientWithTimeout(secret, 0)
+}
+
+// GetRemoteK8sClientWithTimeout은 요청마다 timeout이 적용된 remote cluster client를 반환한다.
+// timeout이 0이면 제한 시간을 두지 않는다.
+func GetRemoteK8sClientWithTimeout(secret *coreV1.Secret, timeout time.Duration) (*kubernetes.Clientset, error) {
 	value, ok := secret.Data["value"]
 	if !ok {
 		err := errors.NewBadRequest("secret does not have a value")
@@ -53,6 +61,7 @@ func GetRemoteK8sClient(secret *coreV1.Secret) (*kubernetes.Clientset, error) {
 	if err != nil {
 		return nil, err
 	}
+	remoteRestConfig.Timeout = timeout
 
 	remoteClientset, err := kubernetes.NewForConfig(remoteRestConfig)
 	if err != nil {
@@ -158,8 +167,10 @@ func URIToSecretName(uriType, uri string) (string, error) {
 func GetProviderName(provider string) (string, error) {
 	provider = strings.ToUpper(provider)
 	providerNameLogo := map[string]string{
-		ProviderAws:     ProviderAwsLogo,
-		ProviderVsphere: ProviderVsphereLogo,
+		ProviderAws:       ProviderAwsLogo,
+		ProviderVsphere:   ProviderVsphereLogo,
+		ProviderOpenstack: ProviderOpenstackLogo,
+		ProviderDocker:    ProviderDockerLogo,
 	}
 
 	if providerNameLogo[provider] == "" {
@@ -199,6 +210,39 @@ func IsClusterHealthy(clientSet *kubernetes.Clientset) bool {
 	return true
 }
 
+type nodeMetricsList struct {
+	Items []struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+		Usage coreV1.ResourceList `json:"usage"`
+	} `json:"items"`
+}
+
+// GetNodeMetrics는 metrics-server로부터 node별 cpu, memory 사용량을 조회한다.
+// metrics-server가 설치되어 있지 않은 경우에는 NotFound 에러를 반환한다.
+func GetNodeMetrics(ctx context.Context, clientSet *kubernetes.Clientset) (map[string]coreV1.ResourceList, error) {
+	resp, err := clientSet.
+		RESTClient().
+		Get().
+		AbsPath("/apis/metrics.k8s.io/v1beta1/nodes").
+		DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := &nodeMetricsList{}
+	if err := json.Unmarshal(resp, metrics); err != nil {
+		return nil, err
+	}
+
+	result := map[string]coreV1.ResourceList{}
+	for _, item := range metrics.Items {
+		result[item.Metadata.Name] = item.Usage
+	}
+	return result, nil
+}
+
 // thumbprint가 colon 없이 들어온다면 colon을 붙인다.
 func AddColonToThumbprint(thumbprint string) (string, error) {
 	if thumbprint == "" {
@@ -233,6 +277,13 @@ func IsVsphereProvider(provider string) bool {
 	return false
 }
 
+func IsOpenstackProvider(provider string) bool {
+	if strings.ToUpper(provider) == ProviderOpenstack {
+		return true
+	}
+	return false
+}
+
 func IsAWSProvider(provider string) bool {
 	if strings.ToUpper(provider) == ProviderAws {
 		return true
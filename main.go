@@ -18,7 +18,11 @@ import (
 	"flag"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
+	// distroless image에는 zoneinfo가 없으므로 maintenance window의 time zone을 위해 포함한다.
+	_ "time/tzdata"
 
 	// +kubebuilder:scaffold:imports
 	argocdV1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
@@ -27,14 +31,22 @@ import (
 	// servicecatalogv1beta1 "github.com/kubernetes-sigs/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	claimV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/claim/v1alpha1"
 	clusterV1alpha1 "github.com/tmax-cloud/hypercloud-multi-operator/apis/cluster/v1alpha1"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/audit"
 	claimController "github.com/tmax-cloud/hypercloud-multi-operator/controllers/claim"
 	clusterController "github.com/tmax-cloud/hypercloud-multi-operator/controllers/cluster"
 	k8scontroller "github.com/tmax-cloud/hypercloud-multi-operator/controllers/k8s"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/metrics"
+	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/provider"
+	_ "github.com/tmax-cloud/hypercloud-multi-operator/controllers/provider/aws"
+	_ "github.com/tmax-cloud/hypercloud-multi-operator/controllers/provider/docker"
+	_ "github.com/tmax-cloud/hypercloud-multi-operator/controllers/provider/openstack"
+	_ "github.com/tmax-cloud/hypercloud-multi-operator/controllers/provider/vsphere"
 	"github.com/tmax-cloud/hypercloud-multi-operator/controllers/util"
 	tmaxv1 "github.com/tmax-cloud/template-operator/api/v1"
 	traefikV1alpha1 "github.com/traefik/traefik/v2/pkg/provider/kubernetes/crd/traefik/v1alpha1"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
@@ -44,6 +56,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
 var (
@@ -68,10 +81,38 @@ func init() {
 func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
+	var statusCollectInterval time.Duration
+	var healthProbeInterval time.Duration
+	var healthFailureThreshold int
+	var expiryWarningPeriod time.Duration
+	var supportedVersions string
+	var auditWebhookURL string
+	var claimApproverGroups string
+	var forbidSelfApproval bool
+	var costEstimateConfigMap string
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.DurationVar(&statusCollectInterval, "status-collect-interval", clusterController.DefaultStatusCollectInterval,
+		"The interval to collect node and resource status of managed clusters.")
+	flag.DurationVar(&healthProbeInterval, "health-probe-interval", clusterController.DefaultHealthProbeInterval,
+		"The interval to probe the api server of managed clusters.")
+	flag.IntVar(&healthFailureThreshold, "health-failure-threshold", clusterController.DefaultHealthProbeFailureThreshold,
+		"The number of consecutive probe failures before a managed cluster is marked as unhealthy.")
+	flag.DurationVar(&expiryWarningPeriod, "expiry-warning-period", clusterController.DefaultExpiryWarningPeriod,
+		"The period before expiry to warn the owner of a managed cluster.")
+	flag.StringVar(&supportedVersions, "supported-versions", "",
+		"The comma-separated kubernetes versions that clusters can be upgraded to. "+
+			"A minor version such as v1.23 allows all of its patch versions. Empty allows all versions.")
+	flag.StringVar(&auditWebhookURL, "audit-webhook-url", "",
+		"The URL to send the history of claims as JSON. Empty disables sending.")
+	flag.StringVar(&claimApproverGroups, "claim-approver-groups", "",
+		"The comma-separated groups of users who can approve or reject claims. Empty allows all users.")
+	flag.BoolVar(&forbidSelfApproval, "forbid-self-approval", false,
+		"Forbid users from approving or rejecting their own claims.")
+	flag.StringVar(&costEstimateConfigMap, "cost-estimate-configmap", "cluster-cost-estimate",
+		"The name of ConfigMap in the operator namespace which has the price tables to estimate the cost of claims. Empty disables cost estimation.")
 
 	DEV_MODE := os.Getenv(util.DEV_MODE)
 
@@ -93,14 +134,15 @@ func main() {
 		LeaderElectionID:           "86810e1d.tmax.io",
 		LeaderElectionResourceLock: "leases",
 	})
-	
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
-	setupReconcilers(mgr)
-	setupWebhooks(mgr)
+	setupReconcilers(mgr, costEstimateConfigMap)
+	setupCollectors(mgr, statusCollectInterval, healthProbeInterval, healthFailureThreshold, expiryWarningPeriod)
+	setupAudit(mgr, auditWebhookURL)
+	setupWebhooks(mgr, supportedVersions, claimApproverGroups, forbidSelfApproval)
 	setupChecks()
 
 	// +kubebuilder:scaffold:builder
@@ -123,61 +165,160 @@ func main() {
 	setupLog.Info("Received SIGTERM, shutting down gracefully...")
 }
 
-func setupReconcilers(mgr ctrl.Manager) {
+func setupReconcilers(mgr ctrl.Manager, costEstimateConfigMap string) {
+	// 가격표는 operator의 namespace에서 조회한다.
+	costEstimateKey := types.NamespacedName{}
+	if ns := os.Getenv(util.POD_NAMESPACE); ns != "" && costEstimateConfigMap != "" {
+		costEstimateKey = types.NamespacedName{Namespace: ns, Name: costEstimateConfigMap}
+	}
 	if err := (&claimController.ClusterClaimReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("ClusterClaim"),
-		Scheme: mgr.GetScheme(),
+		Client:                mgr.GetClient(),
+		Log:                   ctrl.Log.WithName("controllers").WithName("ClusterClaim"),
+		Scheme:                mgr.GetScheme(),
+		Recorder:              mgr.GetEventRecorderFor("clusterclaim-controller"),
+		APIReader:             mgr.GetAPIReader(),
+		CostEstimateConfigMap: costEstimateKey,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterClaim")
 		os.Exit(1)
 	}
 
 	if err := (&clusterController.ClusterManagerReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("ClusterManager"),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("ClusterManager"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("clustermanager-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterManager")
 		os.Exit(1)
 	}
 
 	if err := (&claimController.ClusterUpdateClaimReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("ClusterUpdateClaim"),
-		Scheme: mgr.GetScheme(),
+		Client:    mgr.GetClient(),
+		Log:       ctrl.Log.WithName("controllers").WithName("ClusterUpdateClaim"),
+		Scheme:    mgr.GetScheme(),
+		Recorder:  mgr.GetEventRecorderFor("clusterupdateclaim-controller"),
+		APIReader: mgr.GetAPIReader(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterManager")
 		os.Exit(1)
 	}
 
-	if err := (&k8scontroller.SecretReconciler{
+	if err := (&claimController.ClusterQuotaReconciler{
 		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controller").WithName("secretController"),
+		Log:    ctrl.Log.WithName("controllers").WithName("ClusterQuota"),
 		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterQuota")
+		os.Exit(1)
+	}
+
+	if err := (&k8scontroller.SecretReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("controller").WithName("secretController"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("secret-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "secretController")
 		os.Exit(1)
 	}
 
 	if err := (&clusterController.ClusterRegistrationReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("ClusterRegistration"),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("ClusterRegistration"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("clusterregistration-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterRegistration")
 		os.Exit(1)
 	}
 }
 
-func setupWebhooks(mgr ctrl.Manager) {
+func setupCollectors(mgr ctrl.Manager, statusCollectInterval, healthProbeInterval time.Duration, healthFailureThreshold int, expiryWarningPeriod time.Duration) {
+	if err := (&clusterController.ClusterStatusCollector{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("collectors").WithName("ClusterStatus"),
+		Interval: statusCollectInterval,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create collector", "collector", "ClusterStatus")
+		os.Exit(1)
+	}
+
+	if err := (&clusterController.ClusterHealthProber{
+		Client:           mgr.GetClient(),
+		Log:              ctrl.Log.WithName("collectors").WithName("ClusterHealth"),
+		Interval:         healthProbeInterval,
+		FailureThreshold: healthFailureThreshold,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create collector", "collector", "ClusterHealth")
+		os.Exit(1)
+	}
+
+	if err := (&clusterController.ClusterExpiryChecker{
+		Client:        mgr.GetClient(),
+		Log:           ctrl.Log.WithName("collectors").WithName("ClusterExpiry"),
+		Recorder:      mgr.GetEventRecorderFor("clustermanager-expiry-checker"),
+		WarningPeriod: expiryWarningPeriod,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create collector", "collector", "ClusterExpiry")
+		os.Exit(1)
+	}
+
+	// phase, provider, type별 cluster 수는 --metrics-addr로 scrape할 때 계산한다.
+	ctrlmetrics.Registry.MustRegister(&metrics.ClusterCollector{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("collectors").WithName("ClusterMetrics"),
+	})
+}
+
+func setupAudit(mgr ctrl.Manager, auditWebhookURL string) {
+	if auditWebhookURL == "" {
+		return
+	}
+
+	if err := (&audit.HistoryForwarder{
+		Sink: audit.NewWebhookSink(auditWebhookURL),
+		Log:  ctrl.Log.WithName("audit").WithName("ClaimHistory"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create audit forwarder", "sink", "webhook")
+		os.Exit(1)
+	}
+}
+
+func setupWebhooks(mgr ctrl.Manager, supportedVersions, claimApproverGroups string, forbidSelfApproval bool) {
+	for _, v := range strings.Split(supportedVersions, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			clusterV1alpha1.SupportedVersions = append(clusterV1alpha1.SupportedVersions, v)
+		}
+	}
+	for _, g := range strings.Split(claimApproverGroups, ",") {
+		if g = strings.TrimSpace(g); g != "" {
+			claimV1alpha1.ClaimApproverGroups = append(claimV1alpha1.ClaimApproverGroups, g)
+		}
+	}
+	claimV1alpha1.ForbidSelfApproval = forbidSelfApproval
+	// policy에 의한 자동 승인, 삭제 보호 해제는 operator의 service account로 요청하므로 권한 검사에서 제외한다.
+	// 환경 변수가 없으면 setupChecks에서 시작하지 않는다.
+	if ns, sa := os.Getenv(util.POD_NAMESPACE), os.Getenv(util.POD_SERVICE_ACCOUNT); ns != "" && sa != "" {
+		claimV1alpha1.ClaimControllerUsername = "system:serviceaccount:" + ns + ":" + sa
+		clusterV1alpha1.OperatorUsername = claimV1alpha1.ClaimControllerUsername
+	}
+	claimV1alpha1.ProviderSpecValidator = provider.ValidateClaim
+	quotaValidator := &claimController.ClusterQuotaValidator{Client: mgr.GetClient()}
+	claimV1alpha1.ClusterClaimQuotaValidator = quotaValidator.ValidateClusterClaim
+	claimV1alpha1.ClusterUpdateClaimQuotaValidator = quotaValidator.ValidateClusterUpdateClaim
+	claimV1alpha1.UpgradeVersionValidator = (&claimController.UpgradeVersionValidator{Client: mgr.GetClient()}).ValidateClusterUpdateClaim
 	if err := (&claimV1alpha1.ClusterClaim{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "ClusterClaim")
 		os.Exit(1)
 	}
 
+	claimV1alpha1.SetupClaimCreatorWebhookWithManager(mgr)
+	claimV1alpha1.SetupClaimHistoryWebhookWithManager(mgr)
+	claimV1alpha1.SetupClaimApprovalWebhookWithManager(mgr)
+
 	if err := (&claimV1alpha1.ClusterUpdateClaim{}).SetupWebhookWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create webhook", "webhook", "ClusterRegistration")
+		setupLog.Error(err, "unable to create webhook", "webhook", "ClusterUpdateClaim")
 		os.Exit(1)
 	}
 
@@ -185,6 +326,7 @@ func setupWebhooks(mgr ctrl.Manager) {
 		setupLog.Error(err, "unable to create webhook", "webhook", "ClusterManager")
 		os.Exit(1)
 	}
+	clusterV1alpha1.SetupDeletionProtectionWebhookWithManager(mgr)
 
 	if err := (&clusterV1alpha1.ClusterRegistration{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "ClusterRegistration")